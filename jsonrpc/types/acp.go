@@ -0,0 +1,64 @@
+package types
+
+import (
+	"fmt"
+)
+
+// ACPArgs assemble anyone-can-pay lock args
+/*
+ * ACP lock args layout:
+ *
+ *     pubkey_hash (20) | [minimal_ckb (1)] | [minimal_udt (1)]
+ *
+ * Minimums are positional, so minUDT requires minCKB.
+ */
+func ACPArgs(pubkeyHash Bytes, minCKB, minUDT *uint8) (Bytes, error) {
+	args, err := pubkeyHash.Raw()
+	if err != nil {
+		return "", err
+	}
+
+	if len(args) != 20 {
+		return "", fmt.Errorf("invalid acp pubkey hash, should be 20 bytes")
+	}
+
+	if minUDT != nil && minCKB == nil {
+		return "", fmt.Errorf("invalid acp args, minimal udt requires minimal ckb")
+	}
+
+	if minCKB != nil {
+		args = append(args, *minCKB)
+	}
+
+	if minUDT != nil {
+		args = append(args, *minUDT)
+	}
+
+	return BytesFromRaw(args), nil
+}
+
+// ParseACPArgs parse anyone-can-pay lock args, absent minimums are nil
+func ParseACPArgs(args Bytes) (Bytes, *uint8, *uint8, error) {
+	b, err := args.Raw()
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	if len(b) < 20 || len(b) > 22 {
+		return "", nil, nil, fmt.Errorf("invalid acp args, should be 20 to 22 bytes")
+	}
+
+	var minCKB, minUDT *uint8
+
+	if len(b) > 20 {
+		v := b[20]
+		minCKB = &v
+	}
+
+	if len(b) > 21 {
+		v := b[21]
+		minUDT = &v
+	}
+
+	return BytesFromRaw(b[:20]), minCKB, minUDT, nil
+}
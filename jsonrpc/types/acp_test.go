@@ -0,0 +1,61 @@
+package types
+
+import (
+	"testing"
+)
+
+func TestACPArgs(t *testing.T) {
+	pubkeyHash := Bytes("0xc8328aabcd9b9e8e64fbc566c4385c3bdeb219d7")
+	minCKB, minUDT := uint8(9), uint8(2)
+
+	tests := []struct {
+		minCKB *uint8
+		minUDT *uint8
+		expect Bytes
+	}{
+		{nil, nil, "0xc8328aabcd9b9e8e64fbc566c4385c3bdeb219d7"},
+		{&minCKB, nil, "0xc8328aabcd9b9e8e64fbc566c4385c3bdeb219d709"},
+		{&minCKB, &minUDT, "0xc8328aabcd9b9e8e64fbc566c4385c3bdeb219d70902"},
+	}
+
+	for _, test := range tests {
+		got, err := ACPArgs(pubkeyHash, test.minCKB, test.minUDT)
+		if err != nil {
+			t.Errorf("fail to build acp args: %s\n", err)
+			return
+		}
+
+		if got != test.expect {
+			t.Errorf("mismatch result, expect %v, got %v", test.expect, got)
+			return
+		}
+
+		h, c, u, err := ParseACPArgs(got)
+		if err != nil {
+			t.Errorf("fail to parse acp args: %s\n", err)
+			return
+		}
+
+		if h != pubkeyHash || (c == nil) != (test.minCKB == nil) || (u == nil) != (test.minUDT == nil) {
+			t.Errorf("mismatch parsed args, got %v %v %v", h, c, u)
+			return
+		}
+
+		if (c != nil && *c != *test.minCKB) || (u != nil && *u != *test.minUDT) {
+			t.Errorf("mismatch parsed minimums, got %v %v", *c, *u)
+			return
+		}
+	}
+
+	_, err := ACPArgs(pubkeyHash, nil, &minUDT)
+	if err == nil {
+		t.Errorf("expect error on minimal udt without minimal ckb")
+		return
+	}
+
+	_, _, _, err = ParseACPArgs("0xc8328aabcd9b9e8e64fbc566c4385c3bdeb219d7090201")
+	if err == nil {
+		t.Errorf("expect error on too long acp args")
+		return
+	}
+}
@@ -0,0 +1,263 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Address payload format types, see ckb rfc 0021
+const (
+	addressFull     byte = 0x00
+	addressShort    byte = 0x01
+	addressFullData byte = 0x02
+	addressFullType byte = 0x04
+)
+
+// Address format names of AddressFormat
+const (
+	ShortFormat   = "short"
+	FullFormat    = "full"
+	UnknownFormat = "unknown"
+)
+
+// addressHRPs human readable part of each network
+var addressHRPs = map[string]Network{
+	"ckb": Mainnet,
+	"ckt": Testnet,
+}
+
+// shortAddressScripts scripts referred by code hash index in short format
+var shortAddressScripts = []string{Secp256k1Blake160, Multisig, ACP}
+
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+const (
+	bech32Const  uint32 = 1
+	bech32mConst uint32 = 0x2bc830a3
+)
+
+func bech32Polymod(values []byte) uint32 {
+	gen := []uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+
+	chk := uint32(1)
+	for _, v := range values {
+		b := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := 0; i < 5; i++ {
+			if (b>>uint(i))&1 == 1 {
+				chk ^= gen[i]
+			}
+		}
+	}
+
+	return chk
+}
+
+func bech32HRPExpand(hrp string) []byte {
+	ret := make([]byte, 0, len(hrp)*2+1)
+	for i := 0; i < len(hrp); i++ {
+		ret = append(ret, hrp[i]>>5)
+	}
+	ret = append(ret, 0)
+	for i := 0; i < len(hrp); i++ {
+		ret = append(ret, hrp[i]&31)
+	}
+
+	return ret
+}
+
+// bech32Decode decode bech32 or bech32m string
+/*
+ * Returns hrp, 8-bit payload and the checksum constant. CKB addresses
+ * exceed the 90 chars limit of BIP173, so there is no length limit.
+ */
+func bech32Decode(s string) (string, []byte, uint32, error) {
+	if strings.ToLower(s) != s && strings.ToUpper(s) != s {
+		return "", nil, 0, fmt.Errorf("invalid address, mixed case")
+	}
+	s = strings.ToLower(s)
+
+	pos := strings.LastIndexByte(s, '1')
+	if pos < 1 || pos+7 > len(s) {
+		return "", nil, 0, fmt.Errorf("invalid address, bad separator position")
+	}
+
+	hrp := s[:pos]
+	data := make([]byte, len(s)-pos-1)
+	for i := 0; i < len(data); i++ {
+		d := strings.IndexByte(bech32Charset, s[pos+1+i])
+		if d < 0 {
+			return "", nil, 0, fmt.Errorf("invalid address, bad character %q", s[pos+1+i])
+		}
+
+		data[i] = byte(d)
+	}
+
+	c := bech32Polymod(append(bech32HRPExpand(hrp), data...))
+	if c != bech32Const && c != bech32mConst {
+		return "", nil, 0, fmt.Errorf("invalid address, bad checksum")
+	}
+
+	payload, err := convertBits(data[:len(data)-6], 5, 8, false)
+	if err != nil {
+		return "", nil, 0, err
+	}
+
+	return hrp, payload, c, nil
+}
+
+// convertBits regroup bits, used to convert between 5-bit and 8-bit groups
+func convertBits(data []byte, from, to uint, pad bool) ([]byte, error) {
+	var acc uint32
+	var bits uint
+	maxv := uint32(1)<<to - 1
+
+	ret := make([]byte, 0, len(data)*int(from)/int(to)+1)
+	for _, v := range data {
+		acc = acc<<from | uint32(v)
+		bits += from
+		for bits >= to {
+			bits -= to
+			ret = append(ret, byte(acc>>bits&maxv))
+		}
+	}
+
+	if pad {
+		if bits > 0 {
+			ret = append(ret, byte(acc<<(to-bits)&maxv))
+		}
+	} else if bits >= from || acc<<(to-bits)&maxv != 0 {
+		return nil, fmt.Errorf("invalid address, bad padding")
+	}
+
+	return ret, nil
+}
+
+// DecodeAddress decode ckb address into lock script and network
+func DecodeAddress(addr string) (*Script, Network, error) {
+	hrp, payload, variant, err := bech32Decode(addr)
+	if err != nil {
+		return nil, "", err
+	}
+
+	network, ok := addressHRPs[hrp]
+	if !ok {
+		return nil, "", fmt.Errorf("invalid address, unknown prefix %s", hrp)
+	}
+
+	if len(payload) == 0 {
+		return nil, "", fmt.Errorf("invalid address, empty payload")
+	}
+
+	// Full format uses bech32m, all the others use bech32
+	if (payload[0] == addressFull) != (variant == bech32mConst) {
+		return nil, "", fmt.Errorf("invalid address, wrong checksum variant for format 0x%02x", payload[0])
+	}
+
+	switch payload[0] {
+	case addressShort:
+		if len(payload) < 2 || int(payload[1]) >= len(shortAddressScripts) {
+			return nil, "", fmt.Errorf("invalid short address, unknown code hash index")
+		}
+
+		// Acp args may carry minimal ckb and udt amount, see ckb rfc 0026
+		args := payload[2:]
+		maxArgs := 20
+		if shortAddressScripts[payload[1]] == ACP {
+			maxArgs = 22
+		}
+
+		if len(args) < 20 || len(args) > maxArgs {
+			return nil, "", fmt.Errorf("invalid short address, bad args length %d", len(args))
+		}
+
+		s := &Script{
+			CodeHash: knownCodeHashes[network][shortAddressScripts[payload[1]]],
+			HashType: Type,
+			Args:     BytesFromRaw(args),
+		}
+		return s, network, nil
+	case addressFull:
+		if len(payload) < 1+hashSize+1 {
+			return nil, "", fmt.Errorf("invalid full address, payload too short")
+		}
+
+		t, _, err := decodeScriptHashTypeAt(payload, 1+hashSize)
+		if err != nil {
+			return nil, "", err
+		}
+
+		s := &Script{
+			CodeHash: Hash(BytesFromRaw(payload[1 : 1+hashSize])),
+			HashType: t,
+			Args:     BytesFromRaw(payload[2+hashSize:]),
+		}
+		return s, network, nil
+	case addressFullData, addressFullType:
+		if len(payload) < 1+hashSize {
+			return nil, "", fmt.Errorf("invalid full address, payload too short")
+		}
+
+		t := Data
+		if payload[0] == addressFullType {
+			t = Type
+		}
+
+		s := &Script{
+			CodeHash: Hash(BytesFromRaw(payload[1 : 1+hashSize])),
+			HashType: t,
+			Args:     BytesFromRaw(payload[1+hashSize:]),
+		}
+		return s, network, nil
+	}
+
+	return nil, "", fmt.Errorf("invalid address, unknown format 0x%02x", payload[0])
+}
+
+// DecodeAddressForNetwork decode ckb address, reject address of other network
+func DecodeAddressForNetwork(addr string, expected Network) (*Script, error) {
+	s, network, err := DecodeAddress(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	if network != expected {
+		return nil, fmt.Errorf("address is for %s, expected %s", network, expected)
+	}
+
+	return s, nil
+}
+
+// AddressFormat detect format and network of address without decoding the script
+/*
+ * The deprecated full data and full type formats are reported as full.
+ * Strings that aren't ckb addresses are unknown with an error.
+ */
+func AddressFormat(addr string) (format string, network Network, err error) {
+	hrp, payload, variant, err := bech32Decode(addr)
+	if err != nil {
+		return UnknownFormat, "", err
+	}
+
+	network, ok := addressHRPs[hrp]
+	if !ok {
+		return UnknownFormat, "", fmt.Errorf("invalid address, unknown prefix %s", hrp)
+	}
+
+	if len(payload) == 0 {
+		return UnknownFormat, network, fmt.Errorf("invalid address, empty payload")
+	}
+
+	if (payload[0] == addressFull) != (variant == bech32mConst) {
+		return UnknownFormat, network, fmt.Errorf("invalid address, wrong checksum variant for format 0x%02x", payload[0])
+	}
+
+	switch payload[0] {
+	case addressShort:
+		return ShortFormat, network, nil
+	case addressFull, addressFullData, addressFullType:
+		return FullFormat, network, nil
+	}
+
+	return UnknownFormat, network, fmt.Errorf("invalid address, unknown format 0x%02x", payload[0])
+}
@@ -0,0 +1,100 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDecodeAddress(t *testing.T) {
+	expect := &Script{
+		CodeHash: "0x9bd7e06f3ecf4be0f2fcd2188b23f1b9fcc88e5d4b65a8637b17723bbda3cce8",
+		HashType: Type,
+		Args:     "0xb39bbc0b3673c7d36450bc14cfcdad2d559c6c64",
+	}
+
+	tests := []struct {
+		addr    string
+		network Network
+	}{
+		{"ckb1qyqt8xaupvm8837nv3gtc9x0ekkj64vud3jqfwyw5v", Mainnet},
+		{"ckt1qyqt8xaupvm8837nv3gtc9x0ekkj64vud3jq5t63cs", Testnet},
+		{"ckb1qzda0cr08m85hc8jlnfp3zer7xulejywt49kt2rr0vthywaa50xwsqdnnw7qkdnnclfkg59uzn8umtfd2kwxceqxwquc4", Mainnet},
+		{"ckt1qzda0cr08m85hc8jlnfp3zer7xulejywt49kt2rr0vthywaa50xwsqdnnw7qkdnnclfkg59uzn8umtfd2kwxceqgutnjd", Testnet},
+	}
+
+	for _, test := range tests {
+		got, network, err := DecodeAddress(test.addr)
+		if err != nil {
+			t.Errorf("fail to decode %s: %s\n", test.addr, err)
+			return
+		}
+
+		if network != test.network {
+			t.Errorf("mismatch network of %s, expect %v, got %v", test.addr, test.network, network)
+			return
+		}
+
+		if !reflect.DeepEqual(expect, got) {
+			t.Errorf("mismatch script of %s, expect %v, got %v", test.addr, expect, got)
+			return
+		}
+	}
+
+	// Full format encoded with bech32 instead of bech32m
+	_, _, err := DecodeAddress("ckb1qzda0cr08m85hc8jlnfp3zer7xulejywt49kt2rr0vthywaa50xwsqdnnw7qkdnnclfkg59uzn8umtfd2kwxceqnjssah")
+	if err == nil {
+		t.Errorf("expect error on wrong checksum variant")
+		return
+	}
+}
+
+func TestDecodeAddressForNetwork(t *testing.T) {
+	_, err := DecodeAddressForNetwork("ckb1qyqt8xaupvm8837nv3gtc9x0ekkj64vud3jqfwyw5v", Testnet)
+	if err == nil || err.Error() != "address is for mainnet, expected testnet" {
+		t.Errorf("mismatch error, got %v", err)
+		return
+	}
+
+	_, err = DecodeAddressForNetwork("ckt1qyqt8xaupvm8837nv3gtc9x0ekkj64vud3jq5t63cs", Testnet)
+	if err != nil {
+		t.Errorf("fail to decode testnet address: %s\n", err)
+		return
+	}
+}
+
+func TestAddressFormat(t *testing.T) {
+	tests := []struct {
+		addr    string
+		format  string
+		network Network
+	}{
+		{"ckb1qyqt8xaupvm8837nv3gtc9x0ekkj64vud3jqfwyw5v", ShortFormat, Mainnet},
+		{"ckt1qyqt8xaupvm8837nv3gtc9x0ekkj64vud3jq5t63cs", ShortFormat, Testnet},
+		{"ckb1qzda0cr08m85hc8jlnfp3zer7xulejywt49kt2rr0vthywaa50xwsqdnnw7qkdnnclfkg59uzn8umtfd2kwxceqxwquc4", FullFormat, Mainnet},
+		{"ckt1qzda0cr08m85hc8jlnfp3zer7xulejywt49kt2rr0vthywaa50xwsqdnnw7qkdnnclfkg59uzn8umtfd2kwxceqgutnjd", FullFormat, Testnet},
+	}
+
+	for _, test := range tests {
+		format, network, err := AddressFormat(test.addr)
+		if err != nil {
+			t.Errorf("fail to detect format of %s: %s\n", test.addr, err)
+			return
+		}
+
+		if format != test.format || network != test.network {
+			t.Errorf("mismatch result of %s, expect %v %v, got %v %v", test.addr, test.format, test.network, format, network)
+			return
+		}
+	}
+
+	for _, addr := range []string{
+		"0x9bd7e06f3ecf4be0f2fcd2188b23f1b9fcc88e5d4b65a8637b17723bbda3cce8",
+		"ckb1qzda0cr08m85hc8jlnfp3zer7xulejywt49kt2rr0vthywaa50xwsqdnnw7qkdnnclfkg59uzn8umtfd2kwxceqnjssah",
+	} {
+		format, _, err := AddressFormat(addr)
+		if err == nil || format != UnknownFormat {
+			t.Errorf("mismatch result of %s, expect %v, got %v (%v)", addr, UnknownFormat, format, err)
+			return
+		}
+	}
+}
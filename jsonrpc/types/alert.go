@@ -0,0 +1,240 @@
+package types
+
+// Alert ckb network alert
+type Alert struct {
+	ID          Uint32  `json:"id"`
+	Cancel      Uint32  `json:"cancel"`
+	MinVersion  *string `json:"min_version"`
+	MaxVersion  *string `json:"max_version"`
+	Priority    Uint32  `json:"priority"`
+	NoticeUntil Uint64  `json:"notice_until"`
+	Message     string  `json:"message"`
+	Signatures  []Bytes `json:"signatures"`
+}
+
+// stringOpt convert optional string to optional molecule bytes
+func stringOpt(s *string) *Bytes {
+	if s == nil {
+		return nil
+	}
+
+	b := BytesFromString(*s)
+	return &b
+}
+
+// RawSerialize serialize raw alert, the signed part of alert
+/*
+ * Molecule schema:
+ *
+ *     table RawAlert {
+ *         notice_until: Uint64,
+ *         id:           Uint32,
+ *         cancel:       Uint32,
+ *         priority:     Uint32,
+ *         message:      Bytes,
+ *         min_version:  BytesOpt,
+ *         max_version:  BytesOpt,
+ *     }
+ */
+func (a *Alert) RawSerialize() ([]byte, error) {
+	n, err := a.NoticeUntil.Serialize()
+	if err != nil {
+		return nil, fieldError("notice_until", err)
+	}
+
+	id, err := a.ID.Serialize()
+	if err != nil {
+		return nil, fieldError("id", err)
+	}
+
+	c, err := a.Cancel.Serialize()
+	if err != nil {
+		return nil, fieldError("cancel", err)
+	}
+
+	p, err := a.Priority.Serialize()
+	if err != nil {
+		return nil, fieldError("priority", err)
+	}
+
+	msg := BytesFromString(a.Message)
+	m, err := msg.Serialize()
+	if err != nil {
+		return nil, fieldError("message", err)
+	}
+
+	minVersion, err := SerializeOption(stringOpt(a.MinVersion))
+	if err != nil {
+		return nil, fieldError("min_version", err)
+	}
+
+	maxVersion, err := SerializeOption(stringOpt(a.MaxVersion))
+	if err != nil {
+		return nil, fieldError("max_version", err)
+	}
+
+	return buildTable([][]byte{n, id, c, p, m, minVersion, maxVersion})
+}
+
+// Serialize alert
+/*
+ * Molecule schema:
+ *
+ *     table Alert {
+ *         raw:        RawAlert,
+ *         signatures: BytesVec,
+ *     }
+ */
+func (a *Alert) Serialize() ([]byte, error) {
+	raw, err := a.RawSerialize()
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := SerializeDynVecOf(a.Signatures)
+	if err != nil {
+		return nil, fieldError("signatures", err)
+	}
+
+	return buildTable([][]byte{raw, s})
+}
+
+// Hash alert hash, blake2b-256 of the serialized raw alert, which is signed
+func (a *Alert) Hash() (Hash, error) {
+	raw, err := a.RawSerialize()
+	if err != nil {
+		return "", err
+	}
+
+	return CKBHash(raw), nil
+}
+
+// decodeStringAt decode molecule bytes at offset as string
+func decodeStringAt(b []byte, off int) (string, int, error) {
+	_, size, err := decodeFixVecAt(b, off, 1)
+	if err != nil {
+		return "", 0, err
+	}
+
+	return string(b[off+int(u32Size) : off+size]), size, nil
+}
+
+// decodeStringOptAt decode bytes option occupying the whole field span as string
+func decodeStringOptAt(b []byte, field span) (*string, error) {
+	if field.start == field.end {
+		return nil, nil
+	}
+
+	s, n, err := decodeStringAt(b[:field.end], field.start)
+	if err == nil {
+		err = checkSpan(field, n)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+// decodeRawAlertAt decode raw alert at offset
+func decodeRawAlertAt(b []byte, off int) (*Alert, int, error) {
+	fields, size, err := decodeTableAt(b, off, 7)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	nu, n, err := decodeUint64ValueAt(b[:fields[0].end], fields[0].start)
+	if err == nil {
+		err = checkSpan(fields[0], n)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+
+	u32s := make([]Uint32, 3)
+	for i := 0; i < len(u32s); i++ {
+		f := fields[i+1]
+		u, n, err := decodeUint32ValueAt(b[:f.end], f.start)
+		if err == nil {
+			err = checkSpan(f, n)
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+
+		u32s[i] = u
+	}
+
+	m, n, err := decodeStringAt(b[:fields[4].end], fields[4].start)
+	if err == nil {
+		err = checkSpan(fields[4], n)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+
+	minVersion, err := decodeStringOptAt(b, fields[5])
+	if err != nil {
+		return nil, 0, err
+	}
+
+	maxVersion, err := decodeStringOptAt(b, fields[6])
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return &Alert{
+		ID:          u32s[0],
+		Cancel:      u32s[1],
+		MinVersion:  minVersion,
+		MaxVersion:  maxVersion,
+		Priority:    u32s[2],
+		NoticeUntil: nu,
+		Message:     m,
+	}, size, nil
+}
+
+// decodeAlertAt decode alert at offset
+func decodeAlertAt(b []byte, off int) (*Alert, int, error) {
+	fields, size, err := decodeTableAt(b, off, 2)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	a, n, err := decodeRawAlertAt(b[:fields[0].end], fields[0].start)
+	if err == nil {
+		err = checkSpan(fields[0], n)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ss, n, err := decodeDynVecAt(b[:fields[1].end], fields[1].start)
+	if err == nil {
+		err = checkSpan(fields[1], n)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+
+	a.Signatures = make([]Bytes, len(ss))
+	for i := 0; i < len(ss); i++ {
+		s, n, err := decodeBytesAt(b[:ss[i].end], ss[i].start)
+		if err == nil {
+			err = checkSpan(ss[i], n)
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+
+		a.Signatures[i] = s
+	}
+
+	return a, size, nil
+}
+
+// DeserializeAlert deserialize alert
+func DeserializeAlert(b []byte) (*Alert, error) {
+	a, _, err := decodeAlertAt(b, 0)
+	return a, err
+}
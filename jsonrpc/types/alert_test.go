@@ -0,0 +1,60 @@
+package types
+
+import (
+	"encoding/hex"
+	"reflect"
+	"testing"
+)
+
+func TestAlertSerialize(t *testing.T) {
+	minVersion := "0.42.0"
+	a := Alert{
+		ID:          "0x1",
+		Cancel:      "0x0",
+		MinVersion:  &minVersion,
+		Priority:    "0xa",
+		NoticeUntil: "0x17a7ae8e8f8",
+		Message:     "CKB v0.43 upgrade",
+		Signatures:  []Bytes{"0x1111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111"},
+	}
+
+	b, err := a.Serialize()
+	if err != nil {
+		t.Errorf("fail to serialize alert: %s\n", err)
+		return
+	}
+
+	expect := "ac0000000c0000005f0000005300000020000000280000002c00000030000000340000004900000053000000f8e8e87a7a01000001000000000000000a00000011000000434b422076302e3433207570677261646506000000302e34322e304d00000008000000410000001111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111"
+	if hex.EncodeToString(b) != expect {
+		t.Errorf("mismatch result, expect %v, got %v", expect, hex.EncodeToString(b))
+		return
+	}
+
+	h, err := a.Hash()
+	if err != nil {
+		t.Errorf("fail to hash alert: %s\n", err)
+		return
+	}
+
+	if h != "0x9fe0ee6d5829f6e2363c0bcc77a55cb7ce62ea93766785597c80c6cc79fdd9ac" {
+		t.Errorf("mismatch result, expect %v, got %v", "0x9fe0ee6d5829f6e2363c0bcc77a55cb7ce62ea93766785597c80c6cc79fdd9ac", h)
+		return
+	}
+
+	d, err := DeserializeAlert(b)
+	if err != nil {
+		t.Errorf("fail to deserialize alert: %s\n", err)
+		return
+	}
+
+	if !reflect.DeepEqual(*d, a) {
+		t.Errorf("mismatch result, expect %v, got %v", a, *d)
+		return
+	}
+
+	_, err = DeserializeAlert(b[:len(b)-1])
+	if err == nil {
+		t.Errorf("expect error on truncated alert")
+		return
+	}
+}
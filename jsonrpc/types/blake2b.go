@@ -0,0 +1,159 @@
+package types
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// blake2b implementation with personalization support, see RFC 7693
+/*
+ * The standard library doesn't ship blake2b, and CKB requires the
+ * personalization parameter which most implementations don't expose.
+ */
+
+const blake2bBlockSize = 128
+
+var blake2bIV = [8]uint64{
+	0x6a09e667f3bcc908, 0xbb67ae8584caa73b, 0x3c6ef372fe94f82b, 0xa54ff53a5f1d36f1,
+	0x510e527fade682d1, 0x9b05688c2b3e6c1f, 0x1f83d9abfb41bd6b, 0x5be0cd19137e2179,
+}
+
+var blake2bSigma = [10][16]byte{
+	{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
+	{14, 10, 4, 8, 9, 15, 13, 6, 1, 12, 0, 2, 11, 7, 5, 3},
+	{11, 8, 12, 0, 5, 2, 15, 13, 10, 14, 3, 6, 7, 1, 9, 4},
+	{7, 9, 3, 1, 13, 12, 11, 14, 2, 6, 5, 10, 4, 0, 15, 8},
+	{9, 0, 5, 7, 2, 4, 10, 15, 14, 1, 11, 12, 6, 8, 3, 13},
+	{2, 12, 6, 10, 0, 11, 8, 3, 4, 13, 7, 5, 15, 14, 1, 9},
+	{12, 5, 1, 15, 14, 13, 4, 10, 0, 7, 6, 3, 9, 2, 8, 11},
+	{13, 11, 7, 14, 12, 1, 3, 9, 5, 0, 15, 4, 8, 6, 2, 10},
+	{6, 15, 14, 9, 11, 3, 0, 8, 12, 2, 13, 7, 1, 4, 10, 5},
+	{10, 2, 8, 4, 7, 6, 1, 5, 15, 11, 9, 14, 3, 12, 13, 0},
+}
+
+// blake2bDigest blake2b hash.Hash
+type blake2bDigest struct {
+	h      [8]uint64
+	t      [2]uint64
+	buf    [blake2bBlockSize]byte
+	n      int
+	size   int
+	person [16]byte
+}
+
+// newBlake2b create blake2b digest with output size and personalization
+func newBlake2b(size int, person string) *blake2bDigest {
+	d := &blake2bDigest{size: size}
+	copy(d.person[:], person)
+	d.Reset()
+
+	return d
+}
+
+// Reset reset digest to initial state
+func (d *blake2bDigest) Reset() {
+	d.h = blake2bIV
+	d.h[0] ^= 0x01010000 ^ uint64(d.size)
+	d.h[6] ^= binary.LittleEndian.Uint64(d.person[0:8])
+	d.h[7] ^= binary.LittleEndian.Uint64(d.person[8:16])
+	d.t = [2]uint64{}
+	d.n = 0
+}
+
+// Size output size in bytes
+func (d *blake2bDigest) Size() int {
+	return d.size
+}
+
+// BlockSize block size in bytes
+func (d *blake2bDigest) BlockSize() int {
+	return blake2bBlockSize
+}
+
+// Write absorb bytes
+func (d *blake2bDigest) Write(p []byte) (int, error) {
+	written := len(p)
+
+	for len(p) > 0 {
+		// Only compress a full block once more data arrives, the last
+		// block must be compressed with the final flag.
+		if d.n == blake2bBlockSize {
+			d.increment(blake2bBlockSize)
+			d.compress(false)
+			d.n = 0
+		}
+
+		c := copy(d.buf[d.n:], p)
+		d.n += c
+		p = p[c:]
+	}
+
+	return written, nil
+}
+
+// Sum append digest to in, doesn't change the underlying state
+func (d *blake2bDigest) Sum(in []byte) []byte {
+	f := *d
+
+	f.increment(uint64(f.n))
+	for i := f.n; i < blake2bBlockSize; i++ {
+		f.buf[i] = 0
+	}
+	f.compress(true)
+
+	out := make([]byte, 64)
+	for i := 0; i < 8; i++ {
+		binary.LittleEndian.PutUint64(out[i*8:], f.h[i])
+	}
+
+	return append(in, out[:f.size]...)
+}
+
+func (d *blake2bDigest) increment(n uint64) {
+	var carry uint64
+	d.t[0], carry = bits.Add64(d.t[0], n, 0)
+	d.t[1] += carry
+}
+
+func (d *blake2bDigest) compress(final bool) {
+	var m [16]uint64
+	for i := 0; i < 16; i++ {
+		m[i] = binary.LittleEndian.Uint64(d.buf[i*8:])
+	}
+
+	var v [16]uint64
+	copy(v[:8], d.h[:])
+	copy(v[8:], blake2bIV[:])
+	v[12] ^= d.t[0]
+	v[13] ^= d.t[1]
+	if final {
+		v[14] = ^v[14]
+	}
+
+	g := func(a, b, c, e int, x, y uint64) {
+		v[a] = v[a] + v[b] + x
+		v[e] = bits.RotateLeft64(v[e]^v[a], -32)
+		v[c] = v[c] + v[e]
+		v[b] = bits.RotateLeft64(v[b]^v[c], -24)
+		v[a] = v[a] + v[b] + y
+		v[e] = bits.RotateLeft64(v[e]^v[a], -16)
+		v[c] = v[c] + v[e]
+		v[b] = bits.RotateLeft64(v[b]^v[c], -63)
+	}
+
+	for r := 0; r < 12; r++ {
+		s := &blake2bSigma[r%10]
+		g(0, 4, 8, 12, m[s[0]], m[s[1]])
+		g(1, 5, 9, 13, m[s[2]], m[s[3]])
+		g(2, 6, 10, 14, m[s[4]], m[s[5]])
+		g(3, 7, 11, 15, m[s[6]], m[s[7]])
+		g(0, 5, 10, 15, m[s[8]], m[s[9]])
+		g(1, 6, 11, 12, m[s[10]], m[s[11]])
+		g(2, 7, 8, 13, m[s[12]], m[s[13]])
+		g(3, 4, 9, 14, m[s[14]], m[s[15]])
+	}
+
+	for i := 0; i < 8; i++ {
+		d.h[i] ^= v[i] ^ v[i+8]
+	}
+}
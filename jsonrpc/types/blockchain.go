@@ -2,6 +2,9 @@ package types
 
 // Basic
 
+// Byte ckb byte, '0x' prefix hex of exactly one byte
+type Byte string
+
 // Uint32 ckb uint32, '0x' prefix hex number
 type Uint32 string
 
@@ -11,6 +14,15 @@ type Uint64 string
 // Uint128 ckb uint128, '0x' prefix hex number
 type Uint128 string
 
+// Uint32Vec ckb uint32 vector
+type Uint32Vec []Uint32
+
+// Uint64Vec ckb uint64 vector
+type Uint64Vec []Uint64
+
+// Byte32 ckb byte32, '0x' prefix hex of exactly 32 bytes
+type Byte32 string
+
 // Hash ckb hash, '0x' prefix hex string
 type Hash string
 
@@ -30,8 +42,9 @@ type ProposalShortID string
 
 // Enum values
 const (
-	Data ScriptHashType = "data"
-	Type ScriptHashType = "type"
+	Data  ScriptHashType = "data"
+	Type  ScriptHashType = "type"
+	Data1 ScriptHashType = "data1"
 
 	Code     DepType = "code"
 	DepGroup DepType = "dep_group"
@@ -50,6 +63,9 @@ type OutPoint struct {
 	Index  Uint32 `json:"index"`
 }
 
+// OutPointVec ckb outpoint vector, the data of dep group cells
+type OutPointVec []OutPoint
+
 // CellInput ckb cell input
 type CellInput struct {
 	Since          Uint64   `json:"since"`
@@ -0,0 +1,131 @@
+package types
+
+import (
+	"fmt"
+)
+
+// NewOutPoint create outpoint from tx hash and index
+func NewOutPoint(txHash Hash, index uint32) (*OutPoint, error) {
+	if _, err := txHash.Serialize(); err != nil {
+		return nil, err
+	}
+
+	return &OutPoint{TxHash: txHash, Index: newUint32(index)}, nil
+}
+
+// NewCellDep create cell dep of outpoint
+func NewCellDep(outPoint *OutPoint, depType DepType) *CellDep {
+	return &CellDep{OutPoint: *outPoint, DepType: depType}
+}
+
+// NewCellInput create cell input spending prev without since lock
+func NewCellInput(prev *OutPoint) *CellInput {
+	return NewCellInputWithSince(prev, 0)
+}
+
+// NewCellInputWithSince create cell input spending prev with since
+func NewCellInputWithSince(prev *OutPoint, since uint64) *CellInput {
+	return &CellInput{Since: newUint64(since), PreviousOutput: *prev}
+}
+
+// NewTransaction create empty transaction of version 0
+func NewTransaction() *Transaction {
+	t := &Transaction{
+		CellDeps:    []CellDep{},
+		HeaderDeps:  []Hash{},
+		Inputs:      []CellInput{},
+		Outputs:     []CellOutput{},
+		Witnesses:   []Bytes{},
+		OutputsData: []Bytes{},
+	}
+	t.SetVersion(0)
+
+	return t
+}
+
+// NewCellOutput create cell output of zero capacity
+func NewCellOutput() *CellOutput {
+	return &CellOutput{Capacity: newUint64(0)}
+}
+
+// WithCapacity set capacity in shannon
+func (o *CellOutput) WithCapacity(shannon uint64) *CellOutput {
+	o.Capacity = newUint64(shannon)
+	return o
+}
+
+// WithLock set lock script to a copy of lock
+func (o *CellOutput) WithLock(lock *Script) *CellOutput {
+	o.Lock = *lock
+	return o
+}
+
+// WithType set type script to a copy of typeScript, nil removes type
+func (o *CellOutput) WithType(typeScript *Script) *CellOutput {
+	if typeScript == nil {
+		o.Type = nil
+		return o
+	}
+
+	s := *typeScript
+	o.Type = &s
+
+	return o
+}
+
+// BuildSighashSkeleton assemble secp256k1 single sig transaction to be balanced and signed
+/*
+ * The transaction has the sighash dep group, inputs without since, the
+ * given outputs and a trailing change output of zero capacity locked by
+ * changeArgs. Witnesses are a placeholder for the first input and empty
+ * bytes for the rest. Set the change capacity after fee estimation, then
+ * sign and SetWitnessLock.
+ */
+func BuildSighashSkeleton(network Network, inputs []*OutPoint, outputs []*CellOutput, outputsData []Bytes, changeArgs Bytes) (*Transaction, error) {
+	if len(inputs) == 0 {
+		return nil, fmt.Errorf("invalid skeleton, no inputs")
+	}
+
+	if len(outputs) != len(outputsData) {
+		return nil, fmt.Errorf("outputs and outputs data length mismatch, %d != %d", len(outputs), len(outputsData))
+	}
+
+	dep, err := SighashDep(network)
+	if err != nil {
+		return nil, err
+	}
+
+	change, err := SighashLock(network, changeArgs)
+	if err != nil {
+		return nil, fieldError("change", err)
+	}
+
+	t := NewTransaction()
+	t.AddCellDep(dep)
+
+	for i := 0; i < len(inputs); i++ {
+		if _, err := inputs[i].serializedLen(); err != nil {
+			return nil, fieldError(fmt.Sprintf("inputs[%d].previous_output", i), err)
+		}
+
+		t.Inputs = append(t.Inputs, *NewCellInput(inputs[i]))
+		t.Witnesses = append(t.Witnesses, EmptyBytes())
+	}
+	t.Witnesses[0] = PlaceholderWitness()
+
+	for i := 0; i < len(outputs); i++ {
+		if _, err := outputs[i].SerializedLen(); err != nil {
+			return nil, fieldError(fmt.Sprintf("outputs[%d]", i), err)
+		}
+
+		if _, err := outputsData[i].Raw(); err != nil {
+			return nil, fieldError(fmt.Sprintf("outputs_data[%d]", i), err)
+		}
+
+		t.AddOutput(outputs[i], outputsData[i])
+	}
+
+	t.AddOutput(NewCellOutput().WithLock(change), EmptyBytes())
+
+	return t, nil
+}
@@ -0,0 +1,171 @@
+package types
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestNewCellInput(t *testing.T) {
+	o, err := NewOutPoint("0xee046ce2baeda575266d4164f394c53f66009f64759f7a9f12a014c692e79390", 6)
+	if err != nil {
+		t.Errorf("fail to create outpoint: %s\n", err)
+		return
+	}
+
+	expect := "0000000000000000ee046ce2baeda575266d4164f394c53f66009f64759f7a9f12a014c692e7939006000000"
+
+	input := NewCellInput(o)
+
+	b, err := input.Serialize()
+	if err != nil {
+		t.Errorf("fail to serialize: %s\n", err)
+		return
+	}
+
+	if hex.EncodeToString(b) != expect {
+		t.Errorf("mismatch result, expect %v, got %v", expect, hex.EncodeToString(b))
+		return
+	}
+
+	input = NewCellInputWithSince(o, 0x2003e80032000064)
+	if input.Since != "0x2003e80032000064" || input.PreviousOutput != *o {
+		t.Errorf("mismatch cell input, got %v", input)
+		return
+	}
+}
+
+func TestNewTransaction(t *testing.T) {
+	tx := NewTransaction()
+
+	expect := "340000001c000000200000002400000028000000" + "2c000000" + "30000000" + "00000000" + "00000000" + "00000000" + "00000000" + "04000000" + "04000000"
+
+	b, err := tx.Serialize()
+	if err != nil {
+		t.Errorf("fail to serialize: %s\n", err)
+		return
+	}
+
+	if hex.EncodeToString(b) != expect {
+		t.Errorf("mismatch result, expect %v, got %v", expect, hex.EncodeToString(b))
+		return
+	}
+
+	tx.SetVersion(1)
+	if tx.Version != "0x1" {
+		t.Errorf("mismatch version, expect %v, got %v", "0x1", tx.Version)
+		return
+	}
+
+	err = tx.SetVersionStrict(1)
+	if err == nil {
+		t.Errorf("expect error on version 1")
+		return
+	}
+
+	err = tx.SetVersionStrict(0)
+	if err != nil || tx.Version != "0x0" {
+		t.Errorf("mismatch version, expect %v, got %v (%v)", "0x0", tx.Version, err)
+		return
+	}
+}
+
+func TestNewCellOutput(t *testing.T) {
+	lock, _ := SighashLock(Mainnet, "0xc8328aabcd9b9e8e64fbc566c4385c3bdeb219d7")
+	dao := &Script{
+		CodeHash: "0x82d76d1b75fe2fd9a27dfbaa65a039221a380d76c926f378d3f81cf3e7e13f2e",
+		HashType: Type,
+		Args:     "0x",
+	}
+
+	o := NewCellOutput().WithCapacity(10000000000).WithLock(lock).WithType(dao)
+
+	expect := "9600000010000000180000006100000000e40b5402000000490000001000000030000000310000009bd7e06f3ecf4be0f2fcd2188b23f1b9fcc88e5d4b65a8637b17723bbda3cce80114000000c8328aabcd9b9e8e64fbc566c4385c3bdeb219d73500000010000000300000003100000082d76d1b75fe2fd9a27dfbaa65a039221a380d76c926f378d3f81cf3e7e13f2e0100000000"
+
+	b, err := o.Serialize()
+	if err != nil {
+		t.Errorf("fail to serialize: %s\n", err)
+		return
+	}
+
+	if hex.EncodeToString(b) != expect {
+		t.Errorf("mismatch result, expect %v, got %v", expect, hex.EncodeToString(b))
+		return
+	}
+
+	// Scripts are copied
+	dao.Args = "0x1234"
+	if o.Type.Args != "0x" {
+		t.Errorf("expect type unchanged, got %v", o.Type.Args)
+		return
+	}
+
+	if o.WithType(nil).Type != nil {
+		t.Errorf("expect type removed")
+		return
+	}
+}
+
+func TestBuildSighashSkeleton(t *testing.T) {
+	prev, err := NewOutPoint("0xee046ce2baeda575266d4164f394c53f66009f64759f7a9f12a014c692e79390", 6)
+	if err != nil {
+		t.Errorf("fail to create outpoint: %s\n", err)
+		return
+	}
+
+	lock, err := SighashLock(Mainnet, "0x470dcdc5e44064909650113a274b3b36aecb6dc7")
+	if err != nil {
+		t.Errorf("fail to create lock: %s\n", err)
+		return
+	}
+
+	out := NewCellOutput().WithCapacity(10000000000).WithLock(lock)
+	tx, err := BuildSighashSkeleton(Mainnet, []*OutPoint{prev, prev}, []*CellOutput{out}, []Bytes{EmptyBytes()}, "0xc8328aabcd9b9e8e64fbc566c4385c3bdeb219d7")
+	if err != nil {
+		t.Errorf("fail to build skeleton: %s\n", err)
+		return
+	}
+
+	if len(tx.CellDeps) != 1 || tx.CellDeps[0].DepType != DepGroup || tx.CellDeps[0].OutPoint.TxHash != "0x71a7ba8fc96349fea0ed3a5c47992e3b4084b031a42264a018e0072e8172e46c" {
+		t.Errorf("mismatch cell deps, got %v", tx.CellDeps)
+		return
+	}
+
+	if len(tx.Inputs) != 2 || len(tx.Witnesses) != 2 || tx.Witnesses[0] != PlaceholderWitness() || tx.Witnesses[1] != EmptyBytes() {
+		t.Errorf("mismatch inputs, got %v, witnesses %v", tx.Inputs, tx.Witnesses)
+		return
+	}
+
+	if len(tx.Outputs) != 2 || len(tx.OutputsData) != 2 || tx.Outputs[1].Lock.Args != "0xc8328aabcd9b9e8e64fbc566c4385c3bdeb219d7" || tx.Outputs[1].Capacity != "0x0" {
+		t.Errorf("mismatch outputs, got %v", tx.Outputs)
+		return
+	}
+
+	if _, err := tx.Hash(); err != nil {
+		t.Errorf("fail to hash skeleton: %s\n", err)
+		return
+	}
+
+	_, err = BuildSighashSkeleton(Mainnet, nil, nil, nil, "0xc8328aabcd9b9e8e64fbc566c4385c3bdeb219d7")
+	if err == nil {
+		t.Errorf("expect error on no inputs")
+		return
+	}
+
+	_, err = BuildSighashSkeleton(Mainnet, []*OutPoint{prev}, []*CellOutput{out}, nil, "0xc8328aabcd9b9e8e64fbc566c4385c3bdeb219d7")
+	if err == nil {
+		t.Errorf("expect error on misaligned outputs data")
+		return
+	}
+
+	_, err = BuildSighashSkeleton(Mainnet, []*OutPoint{prev}, nil, nil, "0xc832")
+	if err == nil {
+		t.Errorf("expect error on invalid change args")
+		return
+	}
+
+	_, err = BuildSighashSkeleton("devnet", []*OutPoint{prev}, nil, nil, "0xc8328aabcd9b9e8e64fbc566c4385c3bdeb219d7")
+	if err == nil {
+		t.Errorf("expect error on unknown network")
+		return
+	}
+}
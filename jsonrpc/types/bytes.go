@@ -0,0 +1,95 @@
+package types
+
+import (
+	"encoding/hex"
+	"fmt"
+	"unicode/utf8"
+)
+
+// EmptyBytes empty bytes, serialized as empty fixvec
+func EmptyBytes() Bytes {
+	return Bytes("0x")
+}
+
+// IsEmpty whether bytes is empty, "", "0x" and "0X" are all empty
+func (b Bytes) IsEmpty() bool {
+	return b == "" || b == "0x" || b == "0X"
+}
+
+// BytesFromRaw encode raw bytes into 0x-prefix hex bytes
+func BytesFromRaw(raw []byte) Bytes {
+	return Bytes("0x" + hex.EncodeToString(raw))
+}
+
+// BytesFromString encode utf-8 string into 0x-prefix hex bytes
+func BytesFromString(s string) Bytes {
+	return BytesFromRaw([]byte(s))
+}
+
+// Raw decode bytes into raw bytes
+func (b *Bytes) Raw() ([]byte, error) {
+	inner := string(*b)
+
+	err := check0xPrefix(inner)
+	if err != nil {
+		return nil, fmt.Errorf("invalid bytes, should be 0x-prefix, use \"0x\" for empty bytes")
+	}
+
+	return hex.DecodeString(inner[2:])
+}
+
+// PadLeft pad bytes with leading zeros to n bytes
+func (b *Bytes) PadLeft(n int) (Bytes, error) {
+	raw, err := b.Raw()
+	if err != nil {
+		return "", err
+	}
+
+	if len(raw) > n {
+		return "", fmt.Errorf("bytes length %d exceeds %d", len(raw), n)
+	}
+
+	return BytesFromRaw(append(make([]byte, n-len(raw)), raw...)), nil
+}
+
+// PadRight pad bytes with trailing zeros to n bytes
+func (b *Bytes) PadRight(n int) (Bytes, error) {
+	raw, err := b.Raw()
+	if err != nil {
+		return "", err
+	}
+
+	if len(raw) > n {
+		return "", fmt.Errorf("bytes length %d exceeds %d", len(raw), n)
+	}
+
+	return BytesFromRaw(append(raw, make([]byte, n-len(raw))...)), nil
+}
+
+// MoleculeBytes molecule form of bytes, fixvec framed with length header
+/*
+ * Same as Serialize, use Raw for the bytes without length header and
+ * RawJSONBytes for the hex form used by CKB RPC JsonBytes.
+ */
+func (b *Bytes) MoleculeBytes() ([]byte, error) {
+	return b.Serialize()
+}
+
+// RawJSONBytes plain 0x-prefix hex form, as CKB RPC JsonBytes
+func (b *Bytes) RawJSONBytes() string {
+	return string(*b)
+}
+
+// AsUTF8 decode bytes as utf-8 text
+func (b *Bytes) AsUTF8() (string, error) {
+	raw, err := b.Raw()
+	if err != nil {
+		return "", err
+	}
+
+	if !utf8.Valid(raw) {
+		return "", fmt.Errorf("invalid bytes, not utf-8 text")
+	}
+
+	return string(raw), nil
+}
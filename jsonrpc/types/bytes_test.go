@@ -0,0 +1,114 @@
+package types
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestBytesPad(t *testing.T) {
+	b := Bytes("0x0102")
+
+	got, err := b.PadLeft(4)
+	if err != nil || got != "0x00000102" {
+		t.Errorf("mismatch result, expect %v, got %v (%v)", "0x00000102", got, err)
+		return
+	}
+
+	got, err = b.PadRight(4)
+	if err != nil || got != "0x01020000" {
+		t.Errorf("mismatch result, expect %v, got %v (%v)", "0x01020000", got, err)
+		return
+	}
+
+	_, err = b.PadLeft(1)
+	if err == nil {
+		t.Errorf("expect error on bytes longer than target")
+		return
+	}
+}
+
+func TestMoleculeBytes(t *testing.T) {
+	b := Bytes("0x1234")
+
+	got, err := b.MoleculeBytes()
+	if err != nil {
+		t.Errorf("fail to get molecule bytes: %s\n", err)
+		return
+	}
+
+	expect := []byte{0x02, 0x00, 0x00, 0x00, 0x12, 0x34}
+	if !bytes.Equal(got, expect) {
+		t.Errorf("mismatch result, expect %v, got %v", expect, got)
+		return
+	}
+
+	if b.RawJSONBytes() != "0x1234" {
+		t.Errorf("mismatch result, expect %v, got %v", "0x1234", b.RawJSONBytes())
+		return
+	}
+}
+
+func TestBytesEqual(t *testing.T) {
+	tests := []struct {
+		a, b   Bytes
+		expect bool
+	}{
+		{"0x", "0x", true},
+		{"0xabCD", "0XABcd", true},
+		{"0xabcd", "0xabcd00", false},
+		{"0x", "0x00", false},
+		{"0xzz", "0xZZ", true},
+		{"0xab", "ab", false},
+	}
+
+	for _, test := range tests {
+		if got := test.a.Equal(test.b); got != test.expect {
+			t.Errorf("mismatch result of %v == %v, expect %v, got %v", test.a, test.b, test.expect, got)
+			return
+		}
+	}
+}
+
+func TestBytesIsEmpty(t *testing.T) {
+	for _, b := range []Bytes{"", "0x", "0X", EmptyBytes()} {
+		if !b.IsEmpty() {
+			t.Errorf("mismatch result, expect %v, got %v", true, b.IsEmpty())
+			return
+		}
+	}
+
+	for _, b := range []Bytes{"0x00", "0", "0xx"} {
+		if b.IsEmpty() {
+			t.Errorf("mismatch result, expect %v, got %v", false, b.IsEmpty())
+			return
+		}
+	}
+}
+
+func TestBytesUTF8(t *testing.T) {
+	b := BytesFromString("ckb 你好")
+	if b != "0x636b6220e4bda0e5a5bd" {
+		t.Errorf("mismatch result, expect %v, got %v", "0x636b6220e4bda0e5a5bd", b)
+		return
+	}
+
+	s, err := b.AsUTF8()
+	if err != nil || s != "ckb 你好" {
+		t.Errorf("mismatch result, expect %v, got %v (%v)", "ckb 你好", s, err)
+		return
+	}
+
+	b = "0xe4bd"
+	_, err = b.AsUTF8()
+	if err == nil {
+		t.Errorf("expect error on invalid utf-8")
+		return
+	}
+
+	b = "0x0"
+	_, err = b.AsUTF8()
+	if err == nil {
+		t.Errorf("expect error on invalid bytes")
+		return
+	}
+}
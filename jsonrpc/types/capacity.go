@@ -0,0 +1,175 @@
+package types
+
+import (
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// ShannonsPerCKB shannons of one ckb
+const ShannonsPerCKB uint64 = 100000000
+
+// ShannonToCKB format shannon as decimal ckb amount
+func ShannonToCKB(shannon uint64) string {
+	ckb := shannon / ShannonsPerCKB
+	fraction := shannon % ShannonsPerCKB
+
+	if fraction == 0 {
+		return fmt.Sprintf("%d", ckb)
+	}
+
+	return fmt.Sprintf("%d.%s", ckb, strings.TrimRight(fmt.Sprintf("%08d", fraction), "0"))
+}
+
+// CKBToShannon parse decimal ckb amount to shannon, at most 8 fraction digits
+func CKBToShannon(ckb string) (uint64, error) {
+	whole, fraction := ckb, ""
+	if i := strings.IndexByte(ckb, '.'); i >= 0 {
+		whole, fraction = ckb[:i], ckb[i+1:]
+	}
+
+	if whole == "" || len(fraction) > 8 {
+		return 0, fmt.Errorf("invalid ckb amount %s", ckb)
+	}
+
+	w, err := strconv.ParseUint(whole, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid ckb amount %s", ckb)
+	}
+
+	var f uint64
+	if fraction != "" {
+		f, err = strconv.ParseUint(fraction+strings.Repeat("0", 8-len(fraction)), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid ckb amount %s", ckb)
+		}
+	}
+
+	hi, lo := bits.Mul64(w, ShannonsPerCKB)
+	shannon, carry := bits.Add64(lo, f, 0)
+	if hi != 0 || carry != 0 {
+		return 0, fmt.Errorf("ckb amount %s overflows uint64 shannon", ckb)
+	}
+
+	return shannon, nil
+}
+
+// ParseCapacity parse capacity with optional ckb or shannon suffix
+/*
+ * Suffix is case insensitive and may be separated by spaces, a bare
+ * integer is shannon:
+ *
+ *     "100ckb", "100 CKB", "0.5ckb", "1000000000shannon", "1000000000"
+ */
+func ParseCapacity(s string) (Uint64, error) {
+	v := strings.ToLower(strings.TrimSpace(s))
+
+	var (
+		shannon uint64
+		err     error
+	)
+
+	switch {
+	case strings.HasSuffix(v, "ckb"):
+		shannon, err = CKBToShannon(strings.TrimSpace(strings.TrimSuffix(v, "ckb")))
+	case strings.HasSuffix(v, "shannon"):
+		shannon, err = strconv.ParseUint(strings.TrimSpace(strings.TrimSuffix(v, "shannon")), 10, 64)
+	default:
+		shannon, err = strconv.ParseUint(v, 10, 64)
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("invalid capacity %s", s)
+	}
+
+	return newUint64(shannon), nil
+}
+
+// occupiedBytes bytes occupied by script: code hash, hash type and args
+func (s *Script) occupiedBytes() (uint64, error) {
+	args, err := s.Args.Raw()
+	if err != nil {
+		return 0, err
+	}
+
+	return hashSize + 1 + uint64(len(args)), nil
+}
+
+// OccupiedCapacity shannon occupied by cell output without data
+/*
+ * A cell occupies one ckb per byte of capacity(8), lock and type script.
+ */
+func (o *CellOutput) OccupiedCapacity() (uint64, error) {
+	l, err := o.Lock.occupiedBytes()
+	if err != nil {
+		return 0, err
+	}
+
+	size := 8 + l
+
+	if o.Type != nil {
+		t, err := o.Type.occupiedBytes()
+		if err != nil {
+			return 0, err
+		}
+
+		size += t
+	}
+
+	return size * ShannonsPerCKB, nil
+}
+
+// OccupiedCapacityOfCell shannon occupied by cell output with its data
+/*
+ * The minimal capacity a cell must hold:
+ *
+ *     (8 capacity + lock + type + data bytes) * ShannonsPerCKB
+ *
+ * Scripts count code hash, hash type and args bytes only. This is not the
+ * molecule size of the output, molecule headers and offsets are not
+ * counted toward occupied capacity.
+ */
+func OccupiedCapacityOfCell(output *CellOutput, data Bytes) (uint64, error) {
+	occupied, err := output.OccupiedCapacity()
+	if err != nil {
+		return 0, err
+	}
+
+	d, err := data.Raw()
+	if err != nil {
+		return 0, err
+	}
+
+	hi, lo := bits.Mul64(uint64(len(d)), ShannonsPerCKB)
+	total, carry := bits.Add64(occupied, lo, 0)
+	if hi != 0 || carry != 0 {
+		return 0, fmt.Errorf("occupied capacity overflow")
+	}
+
+	return total, nil
+}
+
+// ValidateCapacityForData check capacity is enough to hold the cell with data
+/*
+ * The same check ckb performs on every output:
+ *
+ *     capacity >= (8 + lock + type + len(data)) * ShannonsPerCKB
+ */
+func (o *CellOutput) ValidateCapacityForData(data Bytes) error {
+	capacity, err := o.Capacity.Uint64()
+	if err != nil {
+		return fieldError("capacity", err)
+	}
+
+	occupied, err := OccupiedCapacityOfCell(o, data)
+	if err != nil {
+		return err
+	}
+
+	if capacity < occupied {
+		return fmt.Errorf("capacity %d less than occupied capacity %d", capacity, occupied)
+	}
+
+	return nil
+}
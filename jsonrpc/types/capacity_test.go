@@ -0,0 +1,157 @@
+package types
+
+import (
+	"testing"
+)
+
+func TestShannonToCKB(t *testing.T) {
+	tests := []struct {
+		shannon uint64
+		expect  string
+	}{
+		{0, "0"},
+		{1, "0.00000001"},
+		{100000000, "1"},
+		{150000000, "1.5"},
+		{500000000000001, "5000000.00000001"},
+	}
+
+	for _, test := range tests {
+		got := ShannonToCKB(test.shannon)
+		if got != test.expect {
+			t.Errorf("mismatch result, expect %v, got %v", test.expect, got)
+			return
+		}
+	}
+}
+
+func TestOccupiedCapacity(t *testing.T) {
+	o := CellOutput{
+		Capacity: "0x0",
+		Lock: Script{
+			CodeHash: "0x9bd7e06f3ecf4be0f2fcd2188b23f1b9fcc88e5d4b65a8637b17723bbda3cce8",
+			HashType: Type,
+			Args:     "0xc8328aabcd9b9e8e64fbc566c4385c3bdeb219d7",
+		},
+	}
+
+	// 8 capacity + 32 code hash + 1 hash type + 20 args
+	got, err := o.OccupiedCapacity()
+	if err != nil || got != 61*ShannonsPerCKB {
+		t.Errorf("mismatch result, expect %v, got %v (%v)", 61*ShannonsPerCKB, got, err)
+		return
+	}
+
+	o.Type = &Script{CodeHash: o.Lock.CodeHash, HashType: Type, Args: "0x"}
+
+	got, err = o.OccupiedCapacity()
+	if err != nil || got != 94*ShannonsPerCKB {
+		t.Errorf("mismatch result, expect %v, got %v (%v)", 94*ShannonsPerCKB, got, err)
+		return
+	}
+}
+
+func TestParseCapacity(t *testing.T) {
+	tests := []struct {
+		s      string
+		expect Uint64
+	}{
+		{"100ckb", "0x2540be400"},
+		{"100 CKB", "0x2540be400"},
+		{"0.5ckb", "0x2faf080"},
+		{"61.00000001 CKB", "0x16b969d01"},
+		{"1000000000shannon", "0x3b9aca00"},
+		{"1000000000", "0x3b9aca00"},
+	}
+
+	for _, test := range tests {
+		got, err := ParseCapacity(test.s)
+		if err != nil {
+			t.Errorf("fail to parse %s: %s\n", test.s, err)
+			return
+		}
+
+		if got != test.expect {
+			t.Errorf("mismatch result, expect %v, got %v", test.expect, got)
+			return
+		}
+	}
+
+	invalid := []string{"", "ckb", "0.000000001ckb", "1.5", "1.5shannon", "-1", "0x10", "184467440738ckb", "+1ckb", "1.+5ckb"}
+	for _, s := range invalid {
+		_, err := ParseCapacity(s)
+		if err == nil {
+			t.Errorf("expect error on invalid capacity %s", s)
+			return
+		}
+	}
+}
+
+func TestOccupiedCapacityOfCell(t *testing.T) {
+	o := CellOutput{
+		Capacity: "0x0",
+		Lock: Script{
+			CodeHash: "0x9bd7e06f3ecf4be0f2fcd2188b23f1b9fcc88e5d4b65a8637b17723bbda3cce8",
+			HashType: Type,
+			Args:     "0xc8328aabcd9b9e8e64fbc566c4385c3bdeb219d7",
+		},
+	}
+
+	// 61 bytes cell output + 8 bytes data
+	got, err := OccupiedCapacityOfCell(&o, "0x0000000000000000")
+	if err != nil || got != 69*ShannonsPerCKB {
+		t.Errorf("mismatch result, expect %v, got %v (%v)", 69*ShannonsPerCKB, got, err)
+		return
+	}
+
+	// Molecule size counts headers, 97 bytes for the 61 bytes output
+	b, _ := o.Serialize()
+	if len(b) != 97 {
+		t.Errorf("mismatch molecule size, expect %v, got %v", 97, len(b))
+		return
+	}
+
+	_, err = OccupiedCapacityOfCell(&o, "0x0")
+	if err == nil {
+		t.Errorf("expect error on invalid data")
+		return
+	}
+}
+
+func TestValidateCapacityForData(t *testing.T) {
+	o := CellOutput{
+		Capacity: "0x19b45a500",
+		Lock: Script{
+			CodeHash: "0x9bd7e06f3ecf4be0f2fcd2188b23f1b9fcc88e5d4b65a8637b17723bbda3cce8",
+			HashType: Type,
+			Args:     "0xc8328aabcd9b9e8e64fbc566c4385c3bdeb219d7",
+		},
+	}
+
+	// exactly 69 ckb for 61 bytes output + 8 bytes data
+	err := o.ValidateCapacityForData("0x0000000000000000")
+	if err != nil {
+		t.Errorf("fail to validate capacity: %s\n", err)
+		return
+	}
+
+	err = o.ValidateCapacityForData("0x000000000000000000")
+	if err == nil {
+		t.Errorf("expect error on capacity less than occupied")
+		return
+	}
+
+	o.Capacity = "0x19b45a4ff"
+	err = o.ValidateCapacityForData("0x0000000000000000")
+	if err == nil {
+		t.Errorf("expect error on capacity less than occupied")
+		return
+	}
+
+	o.Capacity = "0x"
+	err = o.ValidateCapacityForData("0x")
+	if err == nil {
+		t.Errorf("expect error on invalid capacity")
+		return
+	}
+}
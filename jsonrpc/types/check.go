@@ -0,0 +1,73 @@
+package types
+
+import (
+	"fmt"
+)
+
+// checkSerialized re-read headers of serialized tables, enabled by tests of it
+var checkSerialized = false
+
+// checkTableSize check full size header and field offsets of serialized table
+func checkTableSize(b []byte, fieldCount int) error {
+	_, size, err := decodeTableAt(b, 0, fieldCount)
+	if err != nil {
+		return fmt.Errorf("invalid serialized table: %s", err)
+	}
+
+	if size != len(b) {
+		return fmt.Errorf("invalid serialized table, size header %d mismatch length %d", size, len(b))
+	}
+
+	return nil
+}
+
+// checkedTable check serialized table when checkSerialized is enabled
+func checkedTable(b []byte, fieldCount int) ([]byte, error) {
+	if checkSerialized {
+		if err := checkTableSize(b, fieldCount); err != nil {
+			return nil, err
+		}
+	}
+
+	return b, nil
+}
+
+// SerializeChecked serialize script and check its size header
+func (s *Script) SerializeChecked() ([]byte, error) {
+	b, err := s.Serialize()
+	if err != nil {
+		return nil, err
+	}
+
+	return b, checkTableSize(b, 3)
+}
+
+// SerializeChecked serialize cell output and check its size header
+func (o *CellOutput) SerializeChecked() ([]byte, error) {
+	b, err := o.Serialize()
+	if err != nil {
+		return nil, err
+	}
+
+	return b, checkTableSize(b, 3)
+}
+
+// SerializeChecked serialize witness args and check its size header
+func (w *WitnessArgs) SerializeChecked() ([]byte, error) {
+	b, err := w.Serialize()
+	if err != nil {
+		return nil, err
+	}
+
+	return b, checkTableSize(b, 3)
+}
+
+// SerializeChecked serialize raw transaction and check its size header
+func (t *Transaction) SerializeChecked() ([]byte, error) {
+	b, err := t.Serialize()
+	if err != nil {
+		return nil, err
+	}
+
+	return b, checkTableSize(b, 6)
+}
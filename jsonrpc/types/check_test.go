@@ -0,0 +1,88 @@
+package types
+
+import (
+	"testing"
+)
+
+// enableCheckSerialized check serialized table headers until the test ends
+func enableCheckSerialized(t *testing.T) {
+	prev := checkSerialized
+	checkSerialized = true
+
+	t.Cleanup(func() {
+		checkSerialized = prev
+	})
+}
+
+func TestBuildTableChecked(t *testing.T) {
+	tx, _ := loadSyntheticTransaction(t)
+	if tx == nil {
+		return
+	}
+
+	enableCheckSerialized(t)
+
+	_, err := tx.FullSerialize()
+	if err != nil {
+		t.Errorf("fail to serialize with checked tables: %s\n", err)
+		return
+	}
+
+	b, err := buildTable([][]byte{{0x01}, {}})
+	if err != nil {
+		t.Errorf("fail to build checked table: %s\n", err)
+		return
+	}
+
+	// Wrong full size header
+	b[0]++
+	_, err = checkedTable(b, 2)
+	if err == nil {
+		t.Errorf("expect error on wrong size header")
+		return
+	}
+}
+
+func TestSerializeChecked(t *testing.T) {
+	s := Script{
+		CodeHash: "0x9bd7e06f3ecf4be0f2fcd2188b23f1b9fcc88e5d4b65a8637b17723bbda3cce8",
+		HashType: Type,
+		Args:     "0xc8328aabcd9b9e8e64fbc566c4385c3bdeb219d7",
+	}
+
+	b, err := s.SerializeChecked()
+	if err != nil {
+		t.Errorf("fail to serialize checked: %s\n", err)
+		return
+	}
+
+	o := CellOutput{Capacity: "0x0", Lock: s, Type: &s}
+
+	_, err = o.SerializeChecked()
+	if err != nil {
+		t.Errorf("fail to serialize checked: %s\n", err)
+		return
+	}
+
+	// Wrong full size header
+	b[0]--
+	err = checkTableSize(b, 3)
+	if err == nil {
+		t.Errorf("expect error on wrong size header")
+		return
+	}
+
+	// Trailing bytes beyond size header
+	b[0]++
+	err = checkTableSize(append(b, 0x00), 3)
+	if err == nil {
+		t.Errorf("expect error on size header shorter than output")
+		return
+	}
+
+	err = checkTableSize(b, 2)
+	if err == nil {
+		t.Errorf("expect error on wrong field count")
+		return
+	}
+}
@@ -0,0 +1,50 @@
+package types
+
+// Clone copy of script, nil for nil script
+/*
+ * Hash and Bytes are immutable strings, so a shallow copy is already
+ * deep, the method is for symmetry with Transaction.Clone.
+ */
+func (s *Script) Clone() *Script {
+	if s == nil {
+		return nil
+	}
+
+	c := *s
+	return &c
+}
+
+// Clone deep copy of transaction, sharing no slices or scripts with t
+/*
+ * Nil slices stay nil.
+ */
+func (t *Transaction) Clone() *Transaction {
+	if t == nil {
+		return nil
+	}
+
+	c := &Transaction{
+		Version:     t.Version,
+		CellDeps:    cloneSlice(t.CellDeps),
+		HeaderDeps:  cloneSlice(t.HeaderDeps),
+		Inputs:      cloneSlice(t.Inputs),
+		Outputs:     cloneSlice(t.Outputs),
+		Witnesses:   cloneSlice(t.Witnesses),
+		OutputsData: cloneSlice(t.OutputsData),
+	}
+
+	for i := 0; i < len(c.Outputs); i++ {
+		c.Outputs[i].Type = c.Outputs[i].Type.Clone()
+	}
+
+	return c
+}
+
+// cloneSlice shallow copy of s, nil for nil
+func cloneSlice[T any](s []T) []T {
+	if s == nil {
+		return nil
+	}
+
+	return append(make([]T, 0, len(s)), s...)
+}
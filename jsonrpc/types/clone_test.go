@@ -0,0 +1,54 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestScriptClone(t *testing.T) {
+	s := &Script{
+		CodeHash: "0x9bd7e06f3ecf4be0f2fcd2188b23f1b9fcc88e5d4b65a8637b17723bbda3cce8",
+		HashType: Type,
+		Args:     "0xc8328aabcd9b9e8e64fbc566c4385c3bdeb219d7",
+	}
+
+	c := s.Clone()
+	if c == s || *c != *s {
+		t.Errorf("mismatch result, expect %v, got %v", s, c)
+		return
+	}
+
+	c.Args = "0x"
+	if s.Args != "0xc8328aabcd9b9e8e64fbc566c4385c3bdeb219d7" {
+		t.Errorf("mismatch result, expect %v, got %v", "0xc8328aabcd9b9e8e64fbc566c4385c3bdeb219d7", s.Args)
+		return
+	}
+
+	var nilScript *Script
+	if nilScript.Clone() != nil {
+		t.Errorf("mismatch result, expect %v, got %v", nil, nilScript.Clone())
+		return
+	}
+}
+
+func TestTransactionClone(t *testing.T) {
+	tx, _ := loadSyntheticTransaction(t)
+	if tx == nil {
+		return
+	}
+	c := tx.Clone()
+
+	if !reflect.DeepEqual(c, tx) {
+		t.Errorf("mismatch result, expect %v, got %v", tx, c)
+		return
+	}
+
+	c.Outputs[0].Type.Args = "0x01"
+	c.Inputs[0].Since = "0x1"
+	c.Witnesses[0] = "0x"
+
+	if tx.Outputs[0].Type.Args != "0x" || tx.Inputs[0].Since != "0x0" || tx.Witnesses[0] == "0x" {
+		t.Errorf("clone shares data with original, got %v", tx)
+		return
+	}
+}
@@ -0,0 +1,58 @@
+package types
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Equal compare hash by decoded bytes, so that hex casing doesn't matter
+func (h Hash) Equal(other Hash) bool {
+	a, errA := h.Serialize()
+	b, errB := other.Serialize()
+	if errA != nil || errB != nil {
+		return strings.EqualFold(string(h), string(other))
+	}
+
+	return bytes.Equal(a, b)
+}
+
+// Equal compare bytes by decoded bytes, case insensitive if either is not 0x-prefix hex
+/*
+ * Casing of the 0X prefix makes no difference either way, e.g. "0XABcd"
+ * and "0xabCD" are equal.
+ */
+func (b Bytes) Equal(other Bytes) bool {
+	x, errA := decodeHex(string(b))
+	y, errB := decodeHex(string(other))
+	if errA != nil || errB != nil {
+		return strings.EqualFold(string(b), string(other))
+	}
+
+	return bytes.Equal(x, y)
+}
+
+// key normalized outpoint key for comparison
+func (o *OutPoint) key() string {
+	h, err := o.TxHash.Serialize()
+	if err != nil {
+		h = []byte(strings.ToLower(string(o.TxHash)))
+	}
+
+	i, err := o.Index.Uint32()
+	if err != nil {
+		return fmt.Sprintf("%x:%s", h, strings.ToLower(string(o.Index)))
+	}
+
+	return fmt.Sprintf("%x:%d", h, i)
+}
+
+// Equal compare outpoint by normalized tx hash and index
+func (o *OutPoint) Equal(other *OutPoint) bool {
+	return o.key() == other.key()
+}
+
+// Equal compare cell dep by outpoint and dep type
+func (d *CellDep) Equal(other *CellDep) bool {
+	return d.DepType == other.DepType && d.OutPoint.Equal(&other.OutPoint)
+}
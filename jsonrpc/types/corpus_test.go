@@ -0,0 +1,111 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// corpusEntry molecule fixture with its expected decoded value as json
+type corpusEntry struct {
+	Name     string
+	Mol      []byte
+	Expected []byte
+}
+
+// loadCorpus load fixtures of kind from testdata/corpus/<kind>
+/*
+ * Each fixture is a pair of files sharing a name:
+ *
+ *     <name>.mol:  molecule serialized bytes
+ *     <name>.json: expected decoded value in rpc json
+ *
+ * Fixtures are synthetic, dev chain like values rather than transactions
+ * captured from a node. The .mol bytes were encoded from the json by a
+ * separate molecule encoder, not by this package.
+ */
+func loadCorpus(kind string) ([]corpusEntry, error) {
+	files, err := filepath.Glob(filepath.Join("testdata", "corpus", kind, "*.mol"))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no corpus found for %s", kind)
+	}
+
+	entries := make([]corpusEntry, 0, len(files))
+	for _, file := range files {
+		mol, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		expected, err := ioutil.ReadFile(strings.TrimSuffix(file, ".mol") + ".json")
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, corpusEntry{
+			Name:     kind + "/" + strings.TrimSuffix(filepath.Base(file), ".mol"),
+			Mol:      mol,
+			Expected: expected,
+		})
+	}
+
+	return entries, nil
+}
+
+// checkCorpus decode entries, compare with expected and serialize back
+func checkCorpus[T any](t *testing.T, kind string, decode func([]byte) (*T, error), serialize func(*T) ([]byte, error)) {
+	entries, err := loadCorpus(kind)
+	if err != nil {
+		t.Errorf("fail to load corpus: %s\n", err)
+		return
+	}
+
+	for _, e := range entries {
+		got, err := decode(e.Mol)
+		if err != nil {
+			t.Errorf("fail to decode %s: %s\n", e.Name, err)
+			continue
+		}
+
+		var expect T
+		err = json.Unmarshal(e.Expected, &expect)
+		if err != nil {
+			t.Errorf("fail to unmarshal %s: %s\n", e.Name, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(*got, expect) {
+			t.Errorf("mismatch result %s, expect %v, got %v", e.Name, expect, *got)
+			continue
+		}
+
+		b, err := serialize(got)
+		if err != nil {
+			t.Errorf("fail to serialize %s: %s\n", e.Name, err)
+			continue
+		}
+
+		if !bytes.Equal(b, e.Mol) {
+			t.Errorf("mismatch result %s, expect %x, got %x", e.Name, e.Mol, b)
+		}
+	}
+}
+
+func TestCorpus(t *testing.T) {
+	checkCorpus(t, "script", DeserializeScript, (*Script).Serialize)
+	checkCorpus(t, "cell_output", DeserializeCellOutput, (*CellOutput).Serialize)
+	checkCorpus(t, "transaction", DeserializeTransaction, (*Transaction).Serialize)
+	checkCorpus(t, "full_transaction", func(b []byte) (*Transaction, error) {
+		tx, _, err := DeserializeFullTransaction(b)
+		return tx, err
+	}, (*Transaction).FullSerialize)
+}
@@ -0,0 +1,49 @@
+package types
+
+import (
+	"fmt"
+)
+
+// DepositCellData output data of dao deposit cell, 8 zero bytes
+func DepositCellData() Bytes {
+	return BytesFromRaw(make([]byte, 8))
+}
+
+// WithdrawCellData output data of dao withdrawing cell, deposit block number as u64 little-endian
+func WithdrawCellData(depositBlockNumber Uint64) (Bytes, error) {
+	b, err := depositBlockNumber.Serialize()
+	if err != nil {
+		return "", err
+	}
+
+	return BytesFromRaw(b), nil
+}
+
+// ValidateDaoOutputs check output data of dao outputs is 8 bytes
+func (t *Transaction) ValidateDaoOutputs(network Network) error {
+	for i := 0; i < len(t.Outputs); i++ {
+		s := t.Outputs[i].Type
+		if s == nil {
+			continue
+		}
+
+		if name, ok := s.KnownScript(network); !ok || name != DAO {
+			continue
+		}
+
+		if i >= len(t.OutputsData) {
+			return fmt.Errorf("missing outputs_data[%d] of dao output", i)
+		}
+
+		data, err := t.OutputsData[i].Raw()
+		if err != nil {
+			return fieldError(fmt.Sprintf("outputs_data[%d]", i), err)
+		}
+
+		if len(data) != 8 {
+			return fmt.Errorf("invalid outputs_data[%d] of dao output, should be 8 bytes, got %d", i, len(data))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package types
+
+import (
+	"testing"
+)
+
+func TestDaoCellData(t *testing.T) {
+	if DepositCellData() != "0x0000000000000000" {
+		t.Errorf("mismatch result, expect %v, got %v", "0x0000000000000000", DepositCellData())
+		return
+	}
+
+	got, err := WithdrawCellData("0x10de57")
+	if err != nil {
+		t.Errorf("fail to build withdraw data: %s\n", err)
+		return
+	}
+
+	if got != "0x57de100000000000" {
+		t.Errorf("mismatch result, expect %v, got %v", "0x57de100000000000", got)
+		return
+	}
+
+	_, err = WithdrawCellData("10de57")
+	if err == nil {
+		t.Errorf("expect error on invalid block number")
+		return
+	}
+}
+
+func TestValidateDaoOutputs(t *testing.T) {
+	lock, _ := SighashLock(Mainnet, "0xc8328aabcd9b9e8e64fbc566c4385c3bdeb219d7")
+	dao := Script{
+		CodeHash: "0x82d76d1b75fe2fd9a27dfbaa65a039221a380d76c926f378d3f81cf3e7e13f2e",
+		HashType: Type,
+		Args:     "0x",
+	}
+
+	tx := Transaction{}
+	tx.AddOutput(&CellOutput{Capacity: "0x0", Lock: *lock}, "0x1234")
+	tx.AddOutput(&CellOutput{Capacity: "0x0", Lock: *lock, Type: &dao}, DepositCellData())
+
+	err := tx.ValidateDaoOutputs(Mainnet)
+	if err != nil {
+		t.Errorf("fail to validate dao outputs: %s\n", err)
+		return
+	}
+
+	tx.OutputsData[1] = "0x57de1000"
+
+	err = tx.ValidateDaoOutputs(Testnet)
+	if err == nil {
+		t.Errorf("expect error on 4 bytes dao data")
+		return
+	}
+
+	tx.OutputsData = tx.OutputsData[:1]
+
+	err = tx.ValidateDaoOutputs(Mainnet)
+	if err == nil {
+		t.Errorf("expect error on missing dao data")
+		return
+	}
+}
@@ -0,0 +1,29 @@
+package types
+
+// IsDepGroup whether cell dep is a dep group
+func (d *CellDep) IsDepGroup() bool {
+	return d.DepType == DepGroup
+}
+
+// ParseDepGroupData decode dep group cell data into the outpoints it expands to
+/*
+ * A dep group cell data is an OutPointVec, a fixvec of 36 bytes outpoints.
+ */
+func ParseDepGroupData(data Bytes) ([]*OutPoint, error) {
+	b, err := data.Raw()
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := strictDecode(b, decodeOutPointVecAt)
+	if err != nil {
+		return nil, err
+	}
+
+	outPoints := make([]*OutPoint, len(v))
+	for i := 0; i < len(v); i++ {
+		outPoints[i] = &v[i]
+	}
+
+	return outPoints, nil
+}
@@ -0,0 +1,60 @@
+package types
+
+import (
+	"testing"
+)
+
+func TestParseDepGroupData(t *testing.T) {
+	data := Bytes("0x02000000e2fb199810d49a4d8beec56718ba2593b665db9d52299a0f9e6e75416d73ff5c00000000e2fb199810d49a4d8beec56718ba2593b665db9d52299a0f9e6e75416d73ff5c03000000")
+
+	got, err := ParseDepGroupData(data)
+	if err != nil {
+		t.Errorf("fail to parse dep group data: %s\n", err)
+		return
+	}
+
+	expect := []OutPoint{
+		{TxHash: "0xe2fb199810d49a4d8beec56718ba2593b665db9d52299a0f9e6e75416d73ff5c", Index: "0x0"},
+		{TxHash: "0xe2fb199810d49a4d8beec56718ba2593b665db9d52299a0f9e6e75416d73ff5c", Index: "0x3"},
+	}
+	if len(got) != len(expect) || *got[0] != expect[0] || *got[1] != expect[1] {
+		t.Errorf("mismatch result, expect %v, got %v", expect, got)
+		return
+	}
+
+	got, err = ParseDepGroupData("0x00000000")
+	if err != nil || len(got) != 0 {
+		t.Errorf("mismatch result, expect %v, got %v (%v)", 0, len(got), err)
+		return
+	}
+
+	_, err = ParseDepGroupData(data + "00")
+	if err == nil {
+		t.Errorf("expect error on trailing bytes")
+		return
+	}
+
+	_, err = ParseDepGroupData(data[:len(data)-2])
+	if err == nil {
+		t.Errorf("expect error on truncated dep group data")
+		return
+	}
+}
+
+func TestIsDepGroup(t *testing.T) {
+	o, err := NewOutPoint("0x71a7ba8fc96349fea0ed3a5c47992e3b4084b031a42264a018e0072e8172e46c", 0)
+	if err != nil {
+		t.Errorf("fail to create outpoint: %s\n", err)
+		return
+	}
+
+	if !NewCellDep(o, DepGroup).IsDepGroup() {
+		t.Errorf("mismatch result, expect %v, got %v", true, false)
+		return
+	}
+
+	if NewCellDep(o, Code).IsDepGroup() {
+		t.Errorf("mismatch result, expect %v, got %v", false, true)
+		return
+	}
+}
@@ -0,0 +1,237 @@
+package types
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// span absolute [start, end) range of an item inside the decoding buffer
+type span struct {
+	start int
+	end   int
+}
+
+// truncatedError error of buffer ending before size bytes of item at offset
+func truncatedError(off int, size int, item string) error {
+	return fmt.Errorf("truncated at byte %d, expect %d bytes for %s", off, size, item)
+}
+
+// decodeUint32At decode little-endian uint32 at offset
+func decodeUint32At(b []byte, off int) (uint32, int, error) {
+	if off < 0 || len(b)-off < int(u32Size) {
+		return 0, 0, truncatedError(off, int(u32Size), "uint32")
+	}
+
+	return binary.LittleEndian.Uint32(b[off:]), int(u32Size), nil
+}
+
+// decodeFixedAt decode fixed size bytes at offset
+func decodeFixedAt(b []byte, off int, size int) ([]byte, int, error) {
+	if off < 0 || len(b)-off < size {
+		return nil, 0, truncatedError(off, size, "fixed bytes")
+	}
+
+	return b[off : off+size], size, nil
+}
+
+// decodeFixVecAt decode fixvec at offset
+/*
+ * Returns the spans of all items and the bytes consumed by the fixvec,
+ * the item spans are absolute offsets inside b.
+ */
+func decodeFixVecAt(b []byte, off int, itemSize int) ([]span, int, error) {
+	// Zero sized items would let any declared count pass the size check
+	if itemSize <= 0 {
+		return nil, 0, fmt.Errorf("invalid fixvec item size %d", itemSize)
+	}
+
+	count, _, err := decodeUint32At(b, off)
+	if err != nil {
+		return nil, 0, truncatedError(off, int(u32Size), "fixvec item count")
+	}
+
+	size := uint64(u32Size) + uint64(count)*uint64(itemSize)
+	if uint64(len(b)-off) < size {
+		return nil, 0, fmt.Errorf("truncated at byte %d, expect %d bytes for fixvec, got %d", off, size, len(b)-off)
+	}
+
+	items := make([]span, count)
+	start := off + int(u32Size)
+	for i := 0; i < len(items); i++ {
+		items[i] = span{start: start, end: start + itemSize}
+		start += itemSize
+	}
+
+	return items, int(size), nil
+}
+
+// checkSpan check item decoded from span consumed exactly the span
+/*
+ * Bytes left in a field after its item would be ignored, so different
+ * encodings would decode to the same value. Canonical encodings have no
+ * such gaps.
+ */
+func checkSpan(item span, n int) error {
+	if item.end-item.start != n {
+		return fmt.Errorf("invalid item at byte %d, %d bytes span but %d bytes decoded", item.start, item.end-item.start, n)
+	}
+
+	return nil
+}
+
+// decodeHeaderAt decode dynvec or table header at offset
+/*
+ * Returns the item offsets relative to off, followed by the full size as
+ * the end of the last item, and the full size.
+ */
+func decodeHeaderAt(b []byte, off int) ([]int, int, error) {
+	fullSize, _, err := decodeUint32At(b, off)
+	if err != nil {
+		return nil, 0, truncatedError(off, int(u32Size), "dynvec full size")
+	}
+
+	size := int(fullSize)
+	if size < int(u32Size) || len(b)-off < size {
+		return nil, 0, fmt.Errorf("invalid dynvec at byte %d, full size %d out of range, %d bytes left", off, fullSize, len(b)-off)
+	}
+
+	// Empty dyn vector, only the full size
+	if size == int(u32Size) {
+		return []int{size}, size, nil
+	}
+
+	firstOffset, _, err := decodeUint32At(b[:off+size], off+int(u32Size))
+	if err != nil {
+		return nil, 0, truncatedError(off+int(u32Size), int(u32Size), "field offset")
+	}
+
+	if firstOffset%u32Size != 0 || firstOffset < 2*u32Size || int(firstOffset) > size {
+		return nil, 0, fmt.Errorf("invalid dynvec at byte %d, first offset %d out of range", off+int(u32Size), firstOffset)
+	}
+
+	count := int(firstOffset/u32Size) - 1
+	offsets := make([]int, count+1)
+	for i := 0; i < count; i++ {
+		o, _, err := decodeUint32At(b[:off+size], off+int(u32Size)*(i+1))
+		if err != nil {
+			return nil, 0, truncatedError(off+int(u32Size)*(i+1), int(u32Size), "field offset")
+		}
+
+		offsets[i] = int(o)
+	}
+	offsets[count] = size
+
+	for i := 0; i < count; i++ {
+		if offsets[i] > offsets[i+1] {
+			return nil, 0, fmt.Errorf("invalid dynvec at byte %d, offset %d out of order", off+int(u32Size)*(i+1), i)
+		}
+	}
+
+	return offsets, size, nil
+}
+
+// decodeDynVecAt decode dynvec at offset
+/*
+ * Returns the spans of all items and the bytes consumed by the dynvec,
+ * the item spans are absolute offsets inside b.
+ */
+func decodeDynVecAt(b []byte, off int) ([]span, int, error) {
+	offsets, size, err := decodeHeaderAt(b, off)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	items := make([]span, len(offsets)-1)
+	for i := 0; i < len(items); i++ {
+		items[i] = span{start: off + offsets[i], end: off + offsets[i+1]}
+	}
+
+	return items, size, nil
+}
+
+// decodeTableAt decode table at offset
+/*
+ * A table has the same layout as dynvec, but the number of fields is
+ * fixed by the schema.
+ */
+func decodeTableAt(b []byte, off int, fieldCount int) ([]span, int, error) {
+	fields, size, err := decodeDynVecAt(b, off)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if len(fields) != fieldCount {
+		return nil, 0, fmt.Errorf("invalid table at byte %d, expect %d fields, got %d", off, fieldCount, len(fields))
+	}
+
+	return fields, size, nil
+}
+
+// spansToBytes slice items out of buffer
+func spansToBytes(b []byte, items []span) [][]byte {
+	ret := make([][]byte, len(items))
+	for i := 0; i < len(items); i++ {
+		ret[i] = b[items[i].start:items[i].end]
+	}
+
+	return ret
+}
+
+// DeserializeFixVec deserialize fixvec into items
+func DeserializeFixVec(b []byte, itemSize int) ([][]byte, error) {
+	items, _, err := decodeFixVecAt(b, 0, itemSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return spansToBytes(b, items), nil
+}
+
+// DeserializeDynVec deserialize dynvec into items
+func DeserializeDynVec(b []byte) ([][]byte, error) {
+	items, _, err := decodeDynVecAt(b, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return spansToBytes(b, items), nil
+}
+
+// DeserializeTable deserialize table into fields
+func DeserializeTable(b []byte) ([][]byte, error) {
+	fields, _, err := decodeDynVecAt(b, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return spansToBytes(b, fields), nil
+}
+
+// TableFieldOffsets field offsets parsed from table header
+/*
+ * Offsets are relative to the start of the table, the header is
+ * validated but fields are not decoded.
+ */
+func TableFieldOffsets(b []byte) ([]uint32, error) {
+	offsets, _, err := decodeHeaderAt(b, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]uint32, len(offsets)-1)
+	for i := 0; i < len(ret); i++ {
+		ret[i] = uint32(offsets[i])
+	}
+
+	return ret, nil
+}
+
+// DeserializeUnion deserialize union into item id and inner item bytes
+func DeserializeUnion(b []byte) (uint32, []byte, error) {
+	id, n, err := decodeUint32At(b, 0)
+	if err != nil {
+		return 0, nil, truncatedError(0, int(u32Size), "union item id")
+	}
+
+	return id, b[n:], nil
+}
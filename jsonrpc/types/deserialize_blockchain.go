@@ -0,0 +1,831 @@
+package types
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+)
+
+const (
+	hashSize      = 32
+	outPointSize  = hashSize + 4
+	cellInputSize = 8 + outPointSize
+	cellDepSize   = outPointSize + 1
+
+	proposalShortIDSize = 10
+)
+
+// decodeHashAt decode hash at offset
+func decodeHashAt(b []byte, off int) (Hash, int, error) {
+	h, n, err := decodeFixedAt(b, off, hashSize)
+	if err != nil {
+		return "", 0, truncatedError(off, hashSize, "hash")
+	}
+
+	return Hash("0x" + hex.EncodeToString(h)), n, nil
+}
+
+// decodeUint32ValueAt decode uint32 at offset
+func decodeUint32ValueAt(b []byte, off int) (Uint32, int, error) {
+	n, size, err := decodeUint32At(b, off)
+	if err != nil {
+		return "", 0, err
+	}
+
+	return newUint32(n), size, nil
+}
+
+// decodeUint64ValueAt decode uint64 at offset
+func decodeUint64ValueAt(b []byte, off int) (Uint64, int, error) {
+	u, size, err := decodeFixedAt(b, off, 8)
+	if err != nil {
+		return "", 0, truncatedError(off, 8, "uint64")
+	}
+
+	return newUint64(binary.LittleEndian.Uint64(u)), size, nil
+}
+
+// decodeScriptHashTypeAt decode script hash type at offset
+func decodeScriptHashTypeAt(b []byte, off int) (ScriptHashType, int, error) {
+	t, size, err := decodeFixedAt(b, off, 1)
+	if err != nil {
+		return "", 0, truncatedError(off, 1, "script hash type")
+	}
+
+	switch t[0] {
+	case 0:
+		return Data, size, nil
+	case 1:
+		return Type, size, nil
+	case 2:
+		return Data1, size, nil
+	}
+
+	return "", 0, fmt.Errorf("invalid script hash type %d at byte %d", t[0], off)
+}
+
+// decodeDepTypeAt decode dep type at offset
+func decodeDepTypeAt(b []byte, off int) (DepType, int, error) {
+	t, size, err := decodeFixedAt(b, off, 1)
+	if err != nil {
+		return "", 0, truncatedError(off, 1, "dep type")
+	}
+
+	switch t[0] {
+	case 0:
+		return Code, size, nil
+	case 1:
+		return DepGroup, size, nil
+	}
+
+	return "", 0, fmt.Errorf("invalid dep type %d at byte %d", t[0], off)
+}
+
+// decodeBytesAt decode bytes at offset
+func decodeBytesAt(b []byte, off int) (Bytes, int, error) {
+	_, size, err := decodeFixVecAt(b, off, 1)
+	if err != nil {
+		return "", 0, err
+	}
+
+	raw := b[off+int(u32Size) : off+size]
+	return Bytes("0x" + hex.EncodeToString(raw)), size, nil
+}
+
+// decodeBytesOptAt decode bytes option occupying the whole field span
+func decodeBytesOptAt(b []byte, field span) (*Bytes, error) {
+	if field.start == field.end {
+		return nil, nil
+	}
+
+	o, n, err := decodeBytesAt(b[:field.end], field.start)
+	if err == nil {
+		err = checkSpan(field, n)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &o, nil
+}
+
+// decodeScriptAt decode script at offset
+func decodeScriptAt(b []byte, off int) (*Script, int, error) {
+	s := &Script{}
+
+	size, err := decodeScriptInto(s, b, off)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return s, size, nil
+}
+
+// decodeScriptInto decode script at offset into s
+func decodeScriptInto(s *Script, b []byte, off int) (int, error) {
+	fields, size, err := decodeTableAt(b, off, 3)
+	if err != nil {
+		return 0, err
+	}
+
+	h, n, err := decodeHashAt(b[:fields[0].end], fields[0].start)
+	if err == nil {
+		err = checkSpan(fields[0], n)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	t, n, err := decodeScriptHashTypeAt(b[:fields[1].end], fields[1].start)
+	if err == nil {
+		err = checkSpan(fields[1], n)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	a, n, err := decodeBytesAt(b[:fields[2].end], fields[2].start)
+	if err == nil {
+		err = checkSpan(fields[2], n)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	s.CodeHash, s.HashType, s.Args = h, t, a
+
+	return size, nil
+}
+
+// decodeOutPointAt decode outpoint at offset
+func decodeOutPointAt(b []byte, off int) (*OutPoint, int, error) {
+	o := &OutPoint{}
+
+	size, err := decodeOutPointInto(o, b, off)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return o, size, nil
+}
+
+// decodeOutPointInto decode outpoint at offset into o
+func decodeOutPointInto(o *OutPoint, b []byte, off int) (int, error) {
+	if off < 0 || len(b)-off < outPointSize {
+		return 0, truncatedError(off, outPointSize, "outpoint")
+	}
+
+	h, _, err := decodeHashAt(b, off)
+	if err != nil {
+		return 0, err
+	}
+
+	i, _, err := decodeUint32ValueAt(b, off+hashSize)
+	if err != nil {
+		return 0, err
+	}
+
+	o.TxHash, o.Index = h, i
+
+	return outPointSize, nil
+}
+
+// decodeCellInputAt decode cell input at offset
+func decodeCellInputAt(b []byte, off int) (*CellInput, int, error) {
+	c := &CellInput{}
+
+	size, err := decodeCellInputInto(c, b, off)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return c, size, nil
+}
+
+// decodeCellInputInto decode cell input at offset into c
+func decodeCellInputInto(c *CellInput, b []byte, off int) (int, error) {
+	if off < 0 || len(b)-off < cellInputSize {
+		return 0, truncatedError(off, cellInputSize, "cell input")
+	}
+
+	s, n, err := decodeUint64ValueAt(b, off)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = decodeOutPointInto(&c.PreviousOutput, b, off+n)
+	if err != nil {
+		return 0, err
+	}
+
+	c.Since = s
+
+	return cellInputSize, nil
+}
+
+// decodeCellOutputAt decode cell output at offset
+func decodeCellOutputAt(b []byte, off int) (*CellOutput, int, error) {
+	o := &CellOutput{}
+
+	size, err := decodeCellOutputInto(o, b, off)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return o, size, nil
+}
+
+// decodeCellOutputInto decode cell output at offset into o, reusing a non nil o.Type
+func decodeCellOutputInto(o *CellOutput, b []byte, off int) (int, error) {
+	fields, size, err := decodeTableAt(b, off, 3)
+	if err != nil {
+		return 0, err
+	}
+
+	c, n, err := decodeUint64ValueAt(b[:fields[0].end], fields[0].start)
+	if err == nil {
+		err = checkSpan(fields[0], n)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	n, err = decodeScriptInto(&o.Lock, b[:fields[1].end], fields[1].start)
+	if err == nil {
+		err = checkSpan(fields[1], n)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	o.Capacity = c
+
+	if fields[2].start == fields[2].end {
+		o.Type = nil
+		return size, nil
+	}
+
+	// Always a fresh script, a previous type may be shared with other outputs
+	typ := &Script{}
+
+	n, err = decodeScriptInto(typ, b[:fields[2].end], fields[2].start)
+	if err == nil {
+		err = checkSpan(fields[2], n)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	o.Type = typ
+
+	return size, nil
+}
+
+// decodeCellDepAt decode cell dep at offset
+func decodeCellDepAt(b []byte, off int) (*CellDep, int, error) {
+	d := &CellDep{}
+
+	size, err := decodeCellDepInto(d, b, off)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return d, size, nil
+}
+
+// decodeCellDepInto decode cell dep at offset into d
+func decodeCellDepInto(d *CellDep, b []byte, off int) (int, error) {
+	if off < 0 || len(b)-off < cellDepSize {
+		return 0, truncatedError(off, cellDepSize, "cell dep")
+	}
+
+	n, err := decodeOutPointInto(&d.OutPoint, b, off)
+	if err != nil {
+		return 0, err
+	}
+
+	t, _, err := decodeDepTypeAt(b, off+n)
+	if err != nil {
+		return 0, err
+	}
+
+	d.DepType = t
+
+	return cellDepSize, nil
+}
+
+// decodeWitnessArgsAt decode witness args at offset
+func decodeWitnessArgsAt(b []byte, off int) (*WitnessArgs, int, error) {
+	fields, size, err := decodeTableAt(b, off, 3)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	l, err := decodeBytesOptAt(b, fields[0])
+	if err != nil {
+		return nil, 0, err
+	}
+
+	i, err := decodeBytesOptAt(b, fields[1])
+	if err != nil {
+		return nil, 0, err
+	}
+
+	o, err := decodeBytesOptAt(b, fields[2])
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return &WitnessArgs{Lock: l, InputType: i, OutputType: o}, size, nil
+}
+
+// decodeTransactionAt decode raw transaction at offset
+/*
+ * Witnesses are not part of the raw transaction, so the decoded
+ * transaction always has empty witnesses.
+ */
+func decodeTransactionAt(b []byte, off int) (*Transaction, int, error) {
+	tx := &Transaction{}
+
+	size, err := decodeTransactionInto(tx, b, off)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return tx, size, nil
+}
+
+// reuseSlice s resliced to n items if capacity allows, otherwise a new slice
+func reuseSlice[T any](s []T, n int) []T {
+	if s == nil || cap(s) < n {
+		return make([]T, n)
+	}
+
+	return s[:n]
+}
+
+// decodeTransactionInto decode raw transaction at offset into tx, reusing its slices
+func decodeTransactionInto(tx *Transaction, b []byte, off int) (int, error) {
+	fields, size, err := decodeTableAt(b, off, 6)
+	if err != nil {
+		return 0, err
+	}
+
+	v, n, err := decodeUint32ValueAt(b[:fields[0].end], fields[0].start)
+	if err == nil {
+		err = checkSpan(fields[0], n)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	cds, n, err := decodeFixVecAt(b[:fields[1].end], fields[1].start, cellDepSize)
+	if err == nil {
+		err = checkSpan(fields[1], n)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	cellDeps := reuseSlice(tx.CellDeps, len(cds))
+	for i := 0; i < len(cds); i++ {
+		n, err := decodeCellDepInto(&cellDeps[i], b[:cds[i].end], cds[i].start)
+		if err == nil {
+			err = checkSpan(cds[i], n)
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	hds, n, err := decodeFixVecAt(b[:fields[2].end], fields[2].start, hashSize)
+	if err == nil {
+		err = checkSpan(fields[2], n)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	headerDeps := reuseSlice(tx.HeaderDeps, len(hds))
+	for i := 0; i < len(hds); i++ {
+		hd, n, err := decodeHashAt(b[:hds[i].end], hds[i].start)
+		if err == nil {
+			err = checkSpan(hds[i], n)
+		}
+		if err != nil {
+			return 0, err
+		}
+
+		headerDeps[i] = hd
+	}
+
+	ips, n, err := decodeFixVecAt(b[:fields[3].end], fields[3].start, cellInputSize)
+	if err == nil {
+		err = checkSpan(fields[3], n)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	inputs := reuseSlice(tx.Inputs, len(ips))
+	for i := 0; i < len(ips); i++ {
+		n, err := decodeCellInputInto(&inputs[i], b[:ips[i].end], ips[i].start)
+		if err == nil {
+			err = checkSpan(ips[i], n)
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	ops, n, err := decodeDynVecAt(b[:fields[4].end], fields[4].start)
+	if err == nil {
+		err = checkSpan(fields[4], n)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	outputs := reuseSlice(tx.Outputs, len(ops))
+	for i := 0; i < len(ops); i++ {
+		n, err := decodeCellOutputInto(&outputs[i], b[:ops[i].end], ops[i].start)
+		if err == nil {
+			err = checkSpan(ops[i], n)
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	ods, n, err := decodeDynVecAt(b[:fields[5].end], fields[5].start)
+	if err == nil {
+		err = checkSpan(fields[5], n)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	outputsData := reuseSlice(tx.OutputsData, len(ods))
+	for i := 0; i < len(ods); i++ {
+		od, n, err := decodeBytesAt(b[:ods[i].end], ods[i].start)
+		if err == nil {
+			err = checkSpan(ods[i], n)
+		}
+		if err != nil {
+			return 0, err
+		}
+
+		outputsData[i] = od
+	}
+
+	*tx = Transaction{
+		Version:     v,
+		CellDeps:    cellDeps,
+		HeaderDeps:  headerDeps,
+		Inputs:      inputs,
+		Outputs:     outputs,
+		Witnesses:   reuseSlice(tx.Witnesses, 0),
+		OutputsData: outputsData,
+	}
+
+	return size, nil
+}
+
+// decodeUint32VecAt decode uint32 vector at offset
+func decodeUint32VecAt(b []byte, off int) (Uint32Vec, int, error) {
+	items, size, err := decodeFixVecAt(b, off, 4)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	v := make(Uint32Vec, len(items))
+	for i := 0; i < len(items); i++ {
+		u, _, err := decodeUint32ValueAt(b, items[i].start)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		v[i] = u
+	}
+
+	return v, size, nil
+}
+
+// decodeUint64VecAt decode uint64 vector at offset
+func decodeUint64VecAt(b []byte, off int) (Uint64Vec, int, error) {
+	items, size, err := decodeFixVecAt(b, off, 8)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	v := make(Uint64Vec, len(items))
+	for i := 0; i < len(items); i++ {
+		u, _, err := decodeUint64ValueAt(b, items[i].start)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		v[i] = u
+	}
+
+	return v, size, nil
+}
+
+// decodeOutPointVecAt decode outpoint vector at offset
+func decodeOutPointVecAt(b []byte, off int) (OutPointVec, int, error) {
+	items, size, err := decodeFixVecAt(b, off, outPointSize)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	v := make(OutPointVec, len(items))
+	for i := 0; i < len(items); i++ {
+		o, _, err := decodeOutPointAt(b, items[i].start)
+		if err != nil {
+			return nil, 0, indexError(i, err)
+		}
+
+		v[i] = *o
+	}
+
+	return v, size, nil
+}
+
+// DeserializeUint32Vec deserialize uint32 vector
+func DeserializeUint32Vec(b []byte) (Uint32Vec, error) {
+	v, _, err := decodeUint32VecAt(b, 0)
+	return v, err
+}
+
+// DeserializeUint64Vec deserialize uint64 vector
+func DeserializeUint64Vec(b []byte) (Uint64Vec, error) {
+	v, _, err := decodeUint64VecAt(b, 0)
+	return v, err
+}
+
+// DeserializeOutPointVec deserialize outpoint vector
+func DeserializeOutPointVec(b []byte) (OutPointVec, error) {
+	v, _, err := decodeOutPointVecAt(b, 0)
+	return v, err
+}
+
+// decodeFullTransactionAt decode transaction with witnesses at offset
+func decodeFullTransactionAt(b []byte, off int) (*Transaction, int, error) {
+	fields, size, err := decodeTableAt(b, off, 2)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	tx, n, err := decodeTransactionAt(b[:fields[0].end], fields[0].start)
+	if err == nil {
+		err = checkSpan(fields[0], n)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ws, n, err := decodeDynVecAt(b[:fields[1].end], fields[1].start)
+	if err == nil {
+		err = checkSpan(fields[1], n)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+
+	witnesses := make([]Bytes, len(ws))
+	for i := 0; i < len(ws); i++ {
+		w, n, err := decodeBytesAt(b[:ws[i].end], ws[i].start)
+		if err == nil {
+			err = checkSpan(ws[i], n)
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+
+		witnesses[i] = w
+	}
+	tx.Witnesses = witnesses
+
+	return tx, size, nil
+}
+
+// DeserializeScript deserialize script
+func DeserializeScript(b []byte) (*Script, error) {
+	s, _, err := decodeScriptAt(b, 0)
+	return s, err
+}
+
+// DeserializeOutPoint deserialize outpoint
+func DeserializeOutPoint(b []byte) (*OutPoint, error) {
+	o, _, err := decodeOutPointAt(b, 0)
+	return o, err
+}
+
+// DeserializeCellInput deserialize cell input
+func DeserializeCellInput(b []byte) (*CellInput, error) {
+	i, _, err := decodeCellInputAt(b, 0)
+	return i, err
+}
+
+// DeserializeCellOutput deserialize cell output
+func DeserializeCellOutput(b []byte) (*CellOutput, error) {
+	o, _, err := decodeCellOutputAt(b, 0)
+	return o, err
+}
+
+// DeserializeCellDep deserialize cell dep
+func DeserializeCellDep(b []byte) (*CellDep, error) {
+	d, _, err := decodeCellDepAt(b, 0)
+	return d, err
+}
+
+// DeserializeWitnessArgs deserialize witness args
+func DeserializeWitnessArgs(b []byte) (*WitnessArgs, error) {
+	w, _, err := decodeWitnessArgsAt(b, 0)
+	return w, err
+}
+
+// DeserializeTransaction deserialize raw transaction
+func DeserializeTransaction(b []byte) (*Transaction, error) {
+	t, _, err := decodeTransactionAt(b, 0)
+	return t, err
+}
+
+// DeserializeInto deserialize raw transaction into t, reusing its slices
+/*
+ * Slices with enough capacity are resliced and items are decoded in
+ * place, so decoding many transactions into the same receiver saves
+ * allocations. Slices previously read from t share its memory and get
+ * overwritten in place, copy them, e.g. with Clone, to keep them across
+ * calls. Output type scripts are always allocated fresh, scripts pointed
+ * to by t are never written.
+ *
+ * All fields are overwritten and witnesses are emptied. On error t is
+ * left in an unspecified state.
+ */
+func (t *Transaction) DeserializeInto(b []byte) error {
+	_, err := decodeTransactionInto(t, b, 0)
+	return err
+}
+
+// DeserializeTransactionAt deserialize raw transaction embedded at offset
+/*
+ * Returns the transaction and the bytes consumed, so that a transaction
+ * embedded inside a larger molecule buffer can be decoded in place.
+ */
+func DeserializeTransactionAt(b []byte, off int) (*Transaction, int, error) {
+	return decodeTransactionAt(b, off)
+}
+
+// DeserializeFullTransaction deserialize transaction with witnesses
+/*
+ * The inverse of FullSerialize, the returned transaction also carries
+ * the witnesses.
+ */
+func DeserializeFullTransaction(b []byte) (*Transaction, []Bytes, error) {
+	t, _, err := decodeFullTransactionAt(b, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return t, t.Witnesses, nil
+}
+
+// StripWitnesses raw transaction bytes of full transaction bytes, without decoding it
+/*
+ * Only the table headers of the transaction and the raw transaction are
+ * checked, the returned bytes are a sub slice of fullTxBytes.
+ */
+func StripWitnesses(fullTxBytes []byte) ([]byte, error) {
+	fields, _, err := decodeTableAt(fullTxBytes, 0, 2)
+	if err != nil {
+		return nil, err
+	}
+
+	raw := fullTxBytes[:fields[0].end]
+	_, n, err := decodeTableAt(raw, fields[0].start, 6)
+	if err == nil {
+		err = checkSpan(fields[0], n)
+	}
+	if err != nil {
+		return nil, fieldError("raw", err)
+	}
+
+	return raw[fields[0].start:], nil
+}
+
+// decodeHex decode 0x-prefix hex string
+func decodeHex(s string) ([]byte, error) {
+	err := check0xPrefix(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return hex.DecodeString(s[2:])
+}
+
+// DeserializeScriptHex deserialize script from 0x-prefix hex
+func DeserializeScriptHex(s string) (*Script, error) {
+	b, err := decodeHex(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return DeserializeScript(b)
+}
+
+// DeserializeCellOutputHex deserialize cell output from 0x-prefix hex
+func DeserializeCellOutputHex(s string) (*CellOutput, error) {
+	b, err := decodeHex(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return DeserializeCellOutput(b)
+}
+
+// DeserializeTransactionHex deserialize raw transaction from 0x-prefix hex
+func DeserializeTransactionHex(s string) (*Transaction, error) {
+	b, err := decodeHex(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return DeserializeTransaction(b)
+}
+
+// strictDecode decode value at start of b, rejecting trailing bytes
+func strictDecode[T any](b []byte, decode func([]byte, int) (T, int, error)) (T, error) {
+	var zero T
+
+	v, n, err := decode(b, 0)
+	if err != nil {
+		return zero, err
+	}
+
+	if n != len(b) {
+		return zero, fmt.Errorf("invalid molecule, %d trailing bytes at byte %d", len(b)-n, n)
+	}
+
+	return v, nil
+}
+
+// StrictDeserializeScript deserialize script, rejecting trailing bytes
+func StrictDeserializeScript(b []byte) (*Script, error) {
+	return strictDecode(b, decodeScriptAt)
+}
+
+// StrictDeserializeOutPoint deserialize outpoint, rejecting trailing bytes
+func StrictDeserializeOutPoint(b []byte) (*OutPoint, error) {
+	return strictDecode(b, decodeOutPointAt)
+}
+
+// StrictDeserializeCellInput deserialize cell input, rejecting trailing bytes
+func StrictDeserializeCellInput(b []byte) (*CellInput, error) {
+	return strictDecode(b, decodeCellInputAt)
+}
+
+// StrictDeserializeCellOutput deserialize cell output, rejecting trailing bytes
+func StrictDeserializeCellOutput(b []byte) (*CellOutput, error) {
+	return strictDecode(b, decodeCellOutputAt)
+}
+
+// StrictDeserializeCellDep deserialize cell dep, rejecting trailing bytes
+func StrictDeserializeCellDep(b []byte) (*CellDep, error) {
+	return strictDecode(b, decodeCellDepAt)
+}
+
+// StrictDeserializeWitnessArgs deserialize witness args, rejecting trailing bytes
+func StrictDeserializeWitnessArgs(b []byte) (*WitnessArgs, error) {
+	return strictDecode(b, decodeWitnessArgsAt)
+}
+
+// StrictDeserializeTransaction deserialize raw transaction, rejecting trailing bytes
+func StrictDeserializeTransaction(b []byte) (*Transaction, error) {
+	return strictDecode(b, decodeTransactionAt)
+}
+
+// StrictDeserializeFullTransaction deserialize transaction with witnesses, rejecting trailing bytes
+func StrictDeserializeFullTransaction(b []byte) (*Transaction, []Bytes, error) {
+	t, err := strictDecode(b, decodeFullTransactionAt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return t, t.Witnesses, nil
+}
+
+// DeserializeProposalShortIDVec deserialize proposal short id fixvec
+func DeserializeProposalShortIDVec(b []byte) ([]ProposalShortID, error) {
+	items, _, err := decodeFixVecAt(b, 0, proposalShortIDSize)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]ProposalShortID, len(items))
+	for i := 0; i < len(items); i++ {
+		ids[i] = ProposalShortID("0x" + hex.EncodeToString(b[items[i].start:items[i].end]))
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,797 @@
+package types
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDeserializeScript(t *testing.T) {
+	script := `{
+		"code_hash": "0x9bd7e06f3ecf4be0f2fcd2188b23f1b9fcc88e5d4b65a8637b17723bbda3cce8",
+		"hash_type": "type",
+		"args": "0xc8328aabcd9b9e8e64fbc566c4385c3bdeb219d7"
+	}`
+
+	scriptHex := "490000001000000030000000310000009bd7e06f3ecf4be0f2fcd2188b23f1b9fcc88e5d4b65a8637b17723bbda3cce80114000000c8328aabcd9b9e8e64fbc566c4385c3bdeb219d7"
+
+	var expect Script
+
+	err := json.Unmarshal([]byte(script), &expect)
+	if err != nil {
+		t.Errorf("fail to unmarshal test script json: %s\n", err)
+		return
+	}
+
+	b, _ := hex.DecodeString(scriptHex)
+
+	got, err := DeserializeScript(b)
+	if err != nil {
+		t.Errorf("fail to deserialize: %s\n", err)
+		return
+	}
+
+	if !reflect.DeepEqual(&expect, got) {
+		t.Errorf("mismatch result, expect %v, got %v", expect, *got)
+		return
+	}
+}
+
+func TestDeserializeCellOutput(t *testing.T) {
+	output := `{
+		"capacity": "0x666",
+		"lock": {
+			"code_hash": "0xe49352ee4984694d88eb3c1493a33d69d61c786dc5b0a32c4b3978d4fad64379",
+			"hash_type": "type",
+			"args": "0x470dcdc5e44064909650113a274b3b36aecb6dc7"
+		},
+		"type": null
+	}`
+
+	outputHex := "61000000100000001800000061000000660600000000000049000000100000003000000031000000e49352ee4984694d88eb3c1493a33d69d61c786dc5b0a32c4b3978d4fad643790114000000470dcdc5e44064909650113a274b3b36aecb6dc7"
+
+	var expect CellOutput
+
+	err := json.Unmarshal([]byte(output), &expect)
+	if err != nil {
+		t.Errorf("fail to unmarshal test cell output json: %s\n", err)
+		return
+	}
+
+	b, _ := hex.DecodeString(outputHex)
+
+	got, err := DeserializeCellOutput(b)
+	if err != nil {
+		t.Errorf("fail to deserialize: %s\n", err)
+		return
+	}
+
+	if !reflect.DeepEqual(&expect, got) {
+		t.Errorf("mismatch result, expect %v, got %v", expect, *got)
+		return
+	}
+}
+
+func TestDeserializeTransaction(t *testing.T) {
+	transaction := `{
+	  "cell_deps": [
+		{
+		  "out_point": {
+			"tx_hash": "0xb815a396c5226009670e89ee514850dcde452bca746cdd6b41c104b50e559c70",
+			"index": "0x0"
+		  },
+		  "dep_type": "dep_group"
+		}
+	  ],
+	  "header_deps": [],
+	  "inputs": [
+		{
+		  "previous_output": {
+			"tx_hash": "0xee046ce2baeda575266d4164f394c53f66009f64759f7a9f12a014c692e79390",
+			"index": "0x6"
+		  },
+		  "since": "0x0"
+		}
+	  ],
+	  "outputs": [
+		{
+		  "capacity": "0x1c6bf52634000",
+		  "lock": {
+			"args": "0x470dcdc5e44064909650113a274b3b36aecb6dc7",
+			"code_hash": "0x9bd7e06f3ecf4be0f2fcd2188b23f1b9fcc88e5d4b65a8637b17723bbda3cce8",
+			"hash_type": "type"
+		  },
+		  "type": null
+		},
+		{
+		  "capacity": "0x1c6bf52634000",
+		  "lock": {
+			"args": "0xc8328aabcd9b9e8e64fbc566c4385c3bdeb219d7",
+			"code_hash": "0x9bd7e06f3ecf4be0f2fcd2188b23f1b9fcc88e5d4b65a8637b17723bbda3cce8",
+			"hash_type": "type"
+		  },
+		  "type": null
+		}
+	  ],
+	  "outputs_data": ["0x", "0x"],
+	  "version": "0x0",
+	  "witnesses": []
+	}`
+
+	txHex := "5f0100001c00000020000000490000004d0000007d0000004b0100000000000001000000b815a396c5226009670e89ee514850dcde452bca746cdd6b41c104b50e559c70000000000100000000010000000000000000000000ee046ce2baeda575266d4164f394c53f66009f64759f7a9f12a014c692e7939006000000ce0000000c0000006d0000006100000010000000180000006100000000406352bfc60100490000001000000030000000310000009bd7e06f3ecf4be0f2fcd2188b23f1b9fcc88e5d4b65a8637b17723bbda3cce80114000000470dcdc5e44064909650113a274b3b36aecb6dc76100000010000000180000006100000000406352bfc60100490000001000000030000000310000009bd7e06f3ecf4be0f2fcd2188b23f1b9fcc88e5d4b65a8637b17723bbda3cce80114000000c8328aabcd9b9e8e64fbc566c4385c3bdeb219d7140000000c000000100000000000000000000000"
+
+	var expect Transaction
+
+	err := json.Unmarshal([]byte(transaction), &expect)
+	if err != nil {
+		t.Errorf("fail to unmarshal test transaction json: %s\n", err)
+		return
+	}
+
+	b, _ := hex.DecodeString(txHex)
+
+	got, err := DeserializeTransaction(b)
+	if err != nil {
+		t.Errorf("fail to deserialize: %s\n", err)
+		return
+	}
+
+	if !reflect.DeepEqual(&expect, got) {
+		t.Errorf("mismatch result, expect %v, got %v", expect, *got)
+		return
+	}
+
+	// Test embedded transaction, prefix with some unrelated bytes
+	embedded := append([]byte{0xff, 0xff, 0xff}, b...)
+	embedded = append(embedded, 0xff)
+
+	got, n, err := DeserializeTransactionAt(embedded, 3)
+	if err != nil {
+		t.Errorf("fail to deserialize embedded transaction: %s\n", err)
+		return
+	}
+
+	if n != len(b) {
+		t.Errorf("mismatch consumed length, expect %v, got %v", len(b), n)
+		return
+	}
+
+	if !reflect.DeepEqual(&expect, got) {
+		t.Errorf("mismatch result, expect %v, got %v", expect, *got)
+		return
+	}
+
+	// Test truncated transaction
+	_, err = DeserializeTransaction(b[:len(b)-1])
+	if err == nil {
+		t.Errorf("expect error on truncated transaction")
+		return
+	}
+}
+
+func TestDeserializeWitnessArgs(t *testing.T) {
+	lock := Bytes("0x" + hex.EncodeToString(make([]byte, 65)))
+	expect := WitnessArgs{
+		Lock: &lock,
+	}
+
+	b, err := expect.Serialize()
+	if err != nil {
+		t.Errorf("fail to serialize: %s\n", err)
+		return
+	}
+
+	got, err := DeserializeWitnessArgs(b)
+	if err != nil {
+		t.Errorf("fail to deserialize: %s\n", err)
+		return
+	}
+
+	if !reflect.DeepEqual(&expect, got) {
+		t.Errorf("mismatch result, expect %v, got %v", expect, *got)
+		return
+	}
+}
+
+func TestDeserializeUintVec(t *testing.T) {
+	v32 := Uint32Vec{"0x1", "0x666"}
+	expectHex := "020000000100000066060000"
+
+	b, err := v32.Serialize()
+	if err != nil {
+		t.Errorf("fail to serialize: %s\n", err)
+		return
+	}
+
+	if hex.EncodeToString(b) != expectHex {
+		t.Errorf("mismatch result, expect %v, got %v", expectHex, hex.EncodeToString(b))
+		return
+	}
+
+	got32, err := DeserializeUint32Vec(b)
+	if err != nil {
+		t.Errorf("fail to deserialize: %s\n", err)
+		return
+	}
+
+	if !reflect.DeepEqual(v32, got32) {
+		t.Errorf("mismatch result, expect %v, got %v", v32, got32)
+		return
+	}
+
+	v64 := Uint64Vec{"0x1c6bf52634000"}
+	expectHex = "0100000000406352bfc60100"
+
+	b, err = v64.Serialize()
+	if err != nil {
+		t.Errorf("fail to serialize: %s\n", err)
+		return
+	}
+
+	if hex.EncodeToString(b) != expectHex {
+		t.Errorf("mismatch result, expect %v, got %v", expectHex, hex.EncodeToString(b))
+		return
+	}
+
+	got64, err := DeserializeUint64Vec(b)
+	if err != nil {
+		t.Errorf("fail to deserialize: %s\n", err)
+		return
+	}
+
+	if !reflect.DeepEqual(v64, got64) {
+		t.Errorf("mismatch result, expect %v, got %v", v64, got64)
+		return
+	}
+}
+
+func TestDeserializeFullTransaction(t *testing.T) {
+	expect, _ := loadSyntheticTransaction(t)
+	if expect == nil {
+		return
+	}
+
+	b, err := expect.FullSerialize()
+	if err != nil {
+		t.Errorf("fail to full serialize: %s\n", err)
+		return
+	}
+
+	// Witness hash is the hash of full serialized transaction
+	expectHash := Hash("0x40ec5f8a180e8a445dadbe31e91ea3bb42c3b0a3f61bc5fd1fd6e2a26efc8a44")
+	if CKBHash(b) != expectHash {
+		t.Errorf("mismatch witness hash, expect %v, got %v", expectHash, CKBHash(b))
+		return
+	}
+
+	got, witnesses, err := DeserializeFullTransaction(b)
+	if err != nil {
+		t.Errorf("fail to deserialize: %s\n", err)
+		return
+	}
+
+	if !reflect.DeepEqual(expect, got) {
+		t.Errorf("mismatch result, expect %v, got %v", *expect, *got)
+		return
+	}
+
+	if !reflect.DeepEqual(expect.Witnesses, witnesses) {
+		t.Errorf("mismatch witnesses, expect %v, got %v", expect.Witnesses, witnesses)
+		return
+	}
+}
+
+func TestDeserializeHex(t *testing.T) {
+	scriptHex := "0x490000001000000030000000310000009bd7e06f3ecf4be0f2fcd2188b23f1b9fcc88e5d4b65a8637b17723bbda3cce80114000000c8328aabcd9b9e8e64fbc566c4385c3bdeb219d7"
+
+	s, err := DeserializeScriptHex(scriptHex)
+	if err != nil {
+		t.Errorf("fail to deserialize: %s\n", err)
+		return
+	}
+
+	if s.Args != "0xc8328aabcd9b9e8e64fbc566c4385c3bdeb219d7" {
+		t.Errorf("mismatch args, got %v", s.Args)
+		return
+	}
+
+	o := CellOutput{Capacity: "0x666", Lock: *s}
+
+	b, _ := o.Serialize()
+
+	got, err := DeserializeCellOutputHex(string(BytesFromRaw(b)))
+	if err != nil {
+		t.Errorf("fail to deserialize: %s\n", err)
+		return
+	}
+
+	if !reflect.DeepEqual(&o, got) {
+		t.Errorf("mismatch result, expect %v, got %v", o, *got)
+		return
+	}
+
+	tx := Transaction{Version: "0x0", CellDeps: []CellDep{}, HeaderDeps: []Hash{}, Inputs: []CellInput{}, Outputs: []CellOutput{}, OutputsData: []Bytes{}, Witnesses: []Bytes{}}
+
+	b, _ = tx.Serialize()
+
+	gotTx, err := DeserializeTransactionHex(string(BytesFromRaw(b)))
+	if err != nil {
+		t.Errorf("fail to deserialize: %s\n", err)
+		return
+	}
+
+	if !reflect.DeepEqual(&tx, gotTx) {
+		t.Errorf("mismatch result, expect %v, got %v", tx, *gotTx)
+		return
+	}
+
+	// Prefix is required
+	_, err = DeserializeScriptHex(scriptHex[2:])
+	if err == nil {
+		t.Errorf("expect error on missing 0x prefix")
+		return
+	}
+}
+
+func TestTableFieldOffsets(t *testing.T) {
+	scriptHex := "490000001000000030000000310000009bd7e06f3ecf4be0f2fcd2188b23f1b9fcc88e5d4b65a8637b17723bbda3cce80114000000c8328aabcd9b9e8e64fbc566c4385c3bdeb219d7"
+
+	b, _ := hex.DecodeString(scriptHex)
+
+	got, err := TableFieldOffsets(b)
+	if err != nil {
+		t.Errorf("fail to parse offsets: %s\n", err)
+		return
+	}
+
+	expect := []uint32{16, 48, 49}
+	if !reflect.DeepEqual(expect, got) {
+		t.Errorf("mismatch result, expect %v, got %v", expect, got)
+		return
+	}
+
+	got, err = TableFieldOffsets([]byte{0x04, 0x00, 0x00, 0x00})
+	if err != nil || len(got) != 0 {
+		t.Errorf("mismatch result, expect no offsets, got %v (%v)", got, err)
+		return
+	}
+
+	// Offsets out of order
+	b[12] = 0x20
+	_, err = TableFieldOffsets(b)
+	if err == nil {
+		t.Errorf("expect error on offsets out of order")
+		return
+	}
+}
+
+func TestCellOutputTypeRoundTrip(t *testing.T) {
+	lock := Script{
+		CodeHash: "0x9bd7e06f3ecf4be0f2fcd2188b23f1b9fcc88e5d4b65a8637b17723bbda3cce8",
+		HashType: Type,
+		Args:     "0xc8328aabcd9b9e8e64fbc566c4385c3bdeb219d7",
+	}
+
+	// Type with empty args is present, not absent
+	dao := Script{
+		CodeHash: "0x82d76d1b75fe2fd9a27dfbaa65a039221a380d76c926f378d3f81cf3e7e13f2e",
+		HashType: Type,
+		Args:     "0x",
+	}
+
+	tests := []struct {
+		output CellOutput
+		hex    string
+	}{
+		{
+			CellOutput{Capacity: "0x2540be400", Lock: lock},
+			"6100000010000000180000006100000000e40b5402000000490000001000000030000000310000009bd7e06f3ecf4be0f2fcd2188b23f1b9fcc88e5d4b65a8637b17723bbda3cce80114000000c8328aabcd9b9e8e64fbc566c4385c3bdeb219d7",
+		},
+		{
+			CellOutput{Capacity: "0x2540be400", Lock: lock, Type: &dao},
+			"9600000010000000180000006100000000e40b5402000000490000001000000030000000310000009bd7e06f3ecf4be0f2fcd2188b23f1b9fcc88e5d4b65a8637b17723bbda3cce80114000000c8328aabcd9b9e8e64fbc566c4385c3bdeb219d73500000010000000300000003100000082d76d1b75fe2fd9a27dfbaa65a039221a380d76c926f378d3f81cf3e7e13f2e0100000000",
+		},
+	}
+
+	for _, test := range tests {
+		b, err := test.output.Serialize()
+		if err != nil {
+			t.Errorf("fail to serialize: %s\n", err)
+			return
+		}
+
+		if hex.EncodeToString(b) != test.hex {
+			t.Errorf("mismatch result, expect %v, got %v", test.hex, hex.EncodeToString(b))
+			return
+		}
+
+		got, err := DeserializeCellOutput(b)
+		if err != nil {
+			t.Errorf("fail to deserialize: %s\n", err)
+			return
+		}
+
+		if !reflect.DeepEqual(&test.output, got) {
+			t.Errorf("mismatch result, expect %v, got %v", test.output.String(), got.String())
+			return
+		}
+	}
+}
+
+func TestDeserializeCanonical(t *testing.T) {
+	scriptHex := "490000001000000030000000310000009bd7e06f3ecf4be0f2fcd2188b23f1b9fcc88e5d4b65a8637b17723bbda3cce80114000000c8328aabcd9b9e8e64fbc566c4385c3bdeb219d7"
+
+	b, _ := hex.DecodeString(scriptHex)
+
+	_, err := StrictDeserializeScript(b)
+	if err != nil {
+		t.Errorf("fail to strict deserialize: %s\n", err)
+		return
+	}
+
+	// Trailing bytes are ignored unless strict
+	trailing := append(append([]byte{}, b...), 0x00)
+
+	_, err = DeserializeScript(trailing)
+	if err != nil {
+		t.Errorf("fail to deserialize: %s\n", err)
+		return
+	}
+
+	_, err = StrictDeserializeScript(trailing)
+	if err == nil {
+		t.Errorf("expect error on trailing bytes")
+		return
+	}
+
+	// Args field with a gap byte after the bytes, size header grows by one
+	gap := append(append([]byte{}, b...), 0x00)
+	gap[0]++
+
+	_, err = DeserializeScript(gap)
+	if err == nil {
+		t.Errorf("expect error on non canonical args field")
+		return
+	}
+
+	// Hash type field of two bytes, args offset moved by one
+	wide, _ := hex.DecodeString("4a000000100000003000000032000000" +
+		"9bd7e06f3ecf4be0f2fcd2188b23f1b9fcc88e5d4b65a8637b17723bbda3cce8" + "0100" +
+		"14000000c8328aabcd9b9e8e64fbc566c4385c3bdeb219d7")
+
+	_, err = DeserializeScript(wide)
+	if err == nil {
+		t.Errorf("expect error on non canonical hash type field")
+		return
+	}
+}
+
+func TestProposalShortIDVec(t *testing.T) {
+	ids := []ProposalShortID{"0xa0ef4eb5f4ceeb08a4c8", "0x0123456789abcdef0123"}
+	expectHex := "02000000a0ef4eb5f4ceeb08a4c80123456789abcdef0123"
+
+	b, err := SerializeProposalShortIDVec(ids)
+	if err != nil {
+		t.Errorf("fail to serialize: %s\n", err)
+		return
+	}
+
+	if hex.EncodeToString(b) != expectHex {
+		t.Errorf("mismatch result, expect %v, got %v", expectHex, hex.EncodeToString(b))
+		return
+	}
+
+	got, err := DeserializeProposalShortIDVec(b)
+	if err != nil {
+		t.Errorf("fail to deserialize: %s\n", err)
+		return
+	}
+
+	if !reflect.DeepEqual(ids, got) {
+		t.Errorf("mismatch result, expect %v, got %v", ids, got)
+		return
+	}
+
+	// Empty vector
+	b, err = SerializeProposalShortIDVec([]ProposalShortID{})
+	if err != nil || hex.EncodeToString(b) != "00000000" {
+		t.Errorf("mismatch result, expect %v, got %x (%v)", "00000000", b, err)
+		return
+	}
+
+	got, err = DeserializeProposalShortIDVec(b)
+	if err != nil || len(got) != 0 {
+		t.Errorf("mismatch result, expect empty, got %v (%v)", got, err)
+		return
+	}
+
+	_, err = SerializeProposalShortIDVec([]ProposalShortID{"0xa0ef4eb5f4ceeb08a4"})
+	if err == nil {
+		t.Errorf("expect error on 9 bytes proposal short id")
+		return
+	}
+}
+
+func TestDeserializeInto(t *testing.T) {
+	b, err := ioutil.ReadFile(filepath.Join("testdata", "corpus", "transaction", "dao_deposit.mol"))
+	if err != nil {
+		t.Errorf("fail to read corpus: %s\n", err)
+		return
+	}
+
+	other, err := DeserializeTransaction(b)
+	if err != nil {
+		t.Errorf("fail to deserialize transaction: %s\n", err)
+		return
+	}
+
+	// change output first, so the reused outputs[0] must drop its type
+	other.Outputs[0], other.Outputs[1] = other.Outputs[1], other.Outputs[0]
+	other.HeaderDeps = nil
+	ob, err := other.Serialize()
+	if err != nil {
+		t.Errorf("fail to serialize transaction: %s\n", err)
+		return
+	}
+
+	var tx Transaction
+	for _, raw := range [][]byte{b, ob, b, b} {
+		err = tx.DeserializeInto(raw)
+		if err != nil {
+			t.Errorf("fail to deserialize into transaction: %s\n", err)
+			return
+		}
+
+		expect, err := DeserializeTransaction(raw)
+		if err != nil {
+			t.Errorf("fail to deserialize transaction: %s\n", err)
+			return
+		}
+
+		if !reflect.DeepEqual(tx, *expect) {
+			t.Errorf("mismatch result, expect %v, got %v", *expect, tx)
+			return
+		}
+	}
+
+	err = tx.DeserializeInto(b[:len(b)-1])
+	if err == nil {
+		t.Errorf("expect error on truncated transaction")
+		return
+	}
+}
+
+func TestDeserializeIntoSharedType(t *testing.T) {
+	lock := Script{
+		CodeHash: "0x9bd7e06f3ecf4be0f2fcd2188b23f1b9fcc88e5d4b65a8637b17723bbda3cce8",
+		HashType: Type,
+		Args:     "0xc8328aabcd9b9e8e64fbc566c4385c3bdeb219d7",
+	}
+	typ := func(args Bytes) *Script {
+		return &Script{CodeHash: lock.CodeHash, HashType: Data, Args: args}
+	}
+
+	src := NewTransaction()
+	src.AddOutput(&CellOutput{Capacity: "0x0", Lock: lock, Type: typ("0x")}, "0x")
+	src.AddOutput(&CellOutput{Capacity: "0x0", Lock: lock, Type: typ("0x1234")}, "0x")
+
+	b, err := src.Serialize()
+	if err != nil {
+		t.Errorf("fail to serialize transaction: %s\n", err)
+		return
+	}
+
+	// Both outputs point to the same caller owned script
+	shared := typ("0xff")
+	tx := NewTransaction()
+	tx.AddOutput(&CellOutput{Capacity: "0x0", Lock: lock, Type: shared}, "0x")
+	tx.AddOutput(&CellOutput{Capacity: "0x0", Lock: lock, Type: shared}, "0x")
+
+	err = tx.DeserializeInto(b)
+	if err != nil {
+		t.Errorf("fail to deserialize into transaction: %s\n", err)
+		return
+	}
+
+	if tx.Outputs[0].Type.Args != "0x" || tx.Outputs[1].Type.Args != "0x1234" {
+		t.Errorf("mismatch result, expect args 0x and 0x1234, got %v and %v", tx.Outputs[0].Type.Args, tx.Outputs[1].Type.Args)
+		return
+	}
+
+	if shared.Args != "0xff" {
+		t.Errorf("expect shared type untouched, got %v", shared.Args)
+		return
+	}
+}
+
+func benchmarkDeserialize(b *testing.B, decode func([]byte) error) {
+	raw, err := ioutil.ReadFile(filepath.Join("testdata", "corpus", "transaction", "dao_deposit.mol"))
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if err := decode(raw); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDeserializeTransaction(b *testing.B) {
+	benchmarkDeserialize(b, func(raw []byte) error {
+		_, err := DeserializeTransaction(raw)
+		return err
+	})
+}
+
+func BenchmarkDeserializeInto(b *testing.B) {
+	var tx Transaction
+	benchmarkDeserialize(b, tx.DeserializeInto)
+}
+
+func TestDeserializeErrorPosition(t *testing.T) {
+	b, err := ioutil.ReadFile(filepath.Join("testdata", "corpus", "script", "secp256k1_blake160_lock.mol"))
+	if err != nil {
+		t.Errorf("fail to read corpus: %s\n", err)
+		return
+	}
+
+	// args item count at byte 49, after 16 bytes header, code hash and hash type
+	b[49] = 21
+	_, err = DeserializeScript(b)
+	expect := "truncated at byte 49, expect 25 bytes for fixvec, got 24"
+	if err == nil || err.Error() != expect {
+		t.Errorf("mismatch result, expect %v, got %v", expect, err)
+		return
+	}
+
+	b[49] = 20
+	b[48] = 3
+	_, err = DeserializeScript(b)
+	expect = "invalid script hash type 3 at byte 48"
+	if err == nil || err.Error() != expect {
+		t.Errorf("mismatch result, expect %v, got %v", expect, err)
+		return
+	}
+
+	_, err = DeserializeCellInput(make([]byte, 43))
+	expect = "truncated at byte 0, expect 44 bytes for cell input"
+	if err == nil || err.Error() != expect {
+		t.Errorf("mismatch result, expect %v, got %v", expect, err)
+		return
+	}
+}
+
+func TestOutPointVec(t *testing.T) {
+	v := OutPointVec{
+		{TxHash: "0xe2fb199810d49a4d8beec56718ba2593b665db9d52299a0f9e6e75416d73ff5c", Index: "0x0"},
+		{TxHash: "0xe2fb199810d49a4d8beec56718ba2593b665db9d52299a0f9e6e75416d73ff5c", Index: "0x3"},
+	}
+	expectHex := "02000000e2fb199810d49a4d8beec56718ba2593b665db9d52299a0f9e6e75416d73ff5c00000000e2fb199810d49a4d8beec56718ba2593b665db9d52299a0f9e6e75416d73ff5c03000000"
+
+	b, err := v.Serialize()
+	if err != nil {
+		t.Errorf("fail to serialize: %s\n", err)
+		return
+	}
+
+	if hex.EncodeToString(b) != expectHex {
+		t.Errorf("mismatch result, expect %v, got %v", expectHex, hex.EncodeToString(b))
+		return
+	}
+
+	got, err := DeserializeOutPointVec(b)
+	if err != nil {
+		t.Errorf("fail to deserialize: %s\n", err)
+		return
+	}
+
+	if !reflect.DeepEqual(v, got) {
+		t.Errorf("mismatch result, expect %v, got %v", v, got)
+		return
+	}
+
+	empty := OutPointVec{}
+	b, err = empty.Serialize()
+	if err != nil || hex.EncodeToString(b) != "00000000" {
+		t.Errorf("mismatch result, expect %v, got %x (%v)", "00000000", b, err)
+		return
+	}
+
+	got, err = DeserializeOutPointVec(b)
+	if err != nil || len(got) != 0 {
+		t.Errorf("mismatch result, expect %v, got %v (%v)", 0, len(got), err)
+		return
+	}
+
+	// short tx hash makes a 35 bytes item
+	v[1].TxHash = "0xe2fb199810d49a4d8beec56718ba2593b665db9d52299a0f9e6e75416d73ff"
+	_, err = v.Serialize()
+	if err == nil {
+		t.Errorf("expect error on invalid outpoint")
+		return
+	}
+
+	_, err = DeserializeOutPointVec([]byte{0x01, 0x00, 0x00, 0x00, 0x00})
+	if err == nil {
+		t.Errorf("expect error on truncated outpoint")
+		return
+	}
+}
+
+func TestStripWitnesses(t *testing.T) {
+	full, err := ioutil.ReadFile(filepath.Join("testdata", "corpus", "full_transaction", "dao_deposit.mol"))
+	if err != nil {
+		t.Errorf("fail to read corpus: %s\n", err)
+		return
+	}
+
+	raw, err := ioutil.ReadFile(filepath.Join("testdata", "corpus", "transaction", "dao_deposit.mol"))
+	if err != nil {
+		t.Errorf("fail to read corpus: %s\n", err)
+		return
+	}
+
+	got, err := StripWitnesses(full)
+	if err != nil {
+		t.Errorf("fail to strip witnesses: %s\n", err)
+		return
+	}
+
+	if !bytes.Equal(got, raw) {
+		t.Errorf("mismatch result, expect %x, got %x", raw, got)
+		return
+	}
+
+	if CKBHash(got) != "0x99d6e242e482489434853da49922e3a24cb40212e1faf1715f82df9cea9a72e1" {
+		t.Errorf("mismatch result, expect %v, got %v", "0x99d6e242e482489434853da49922e3a24cb40212e1faf1715f82df9cea9a72e1", CKBHash(got))
+		return
+	}
+
+	// a raw transaction is a table of 6 fields, not 2
+	_, err = StripWitnesses(raw)
+	if err == nil {
+		t.Errorf("expect error on raw transaction")
+		return
+	}
+
+	_, err = StripWitnesses(full[:len(full)-1])
+	if err == nil {
+		t.Errorf("expect error on truncated transaction")
+		return
+	}
+}
+
+func TestDeserializeHugeCount(t *testing.T) {
+	// A billion items declared in a 10-byte buffer
+	fixvec, _ := hex.DecodeString("00ca9a3b000000000000")
+	for _, itemSize := range []int{0, 1, 32} {
+		_, err := DeserializeFixVec(fixvec, itemSize)
+		if err == nil {
+			t.Errorf("expect error on huge fixvec count with item size %d", itemSize)
+			return
+		}
+	}
+
+	dynvecs := []string{
+		// full size of a billion bytes
+		"00ca9a3b000000000000",
+		// full size 10, first offset of a billion items
+		"0a00000000286bee0000",
+	}
+	for _, v := range dynvecs {
+		b, _ := hex.DecodeString(v)
+		_, err := DeserializeDynVec(b)
+		if err == nil {
+			t.Errorf("expect error on huge dynvec count %s", v)
+			return
+		}
+	}
+}
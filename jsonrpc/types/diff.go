@@ -0,0 +1,145 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// differ collect differences along with field path
+type differ struct {
+	diffs []string
+}
+
+func (d *differ) add(path string, a, b interface{}) {
+	d.diffs = append(d.diffs, fmt.Sprintf("%s: %v != %v", path, a, b))
+}
+
+func (d *differ) hash(path string, a, b Hash) {
+	if !a.Equal(b) {
+		d.add(path, a, b)
+	}
+}
+
+func (d *differ) uint32(path string, a, b Uint32) {
+	x, errA := a.Uint32()
+	y, errB := b.Uint32()
+	if errA != nil || errB != nil {
+		if !strings.EqualFold(string(a), string(b)) {
+			d.add(path, a, b)
+		}
+		return
+	}
+
+	if x != y {
+		d.add(path, a, b)
+	}
+}
+
+func (d *differ) uint64(path string, a, b Uint64) {
+	x, errA := a.Uint64()
+	y, errB := b.Uint64()
+	if errA != nil || errB != nil {
+		if !strings.EqualFold(string(a), string(b)) {
+			d.add(path, a, b)
+		}
+		return
+	}
+
+	if x != y {
+		d.add(path, a, b)
+	}
+}
+
+func (d *differ) bytes(path string, a, b Bytes) {
+	if !a.Equal(b) {
+		d.add(path, a, b)
+	}
+}
+
+func (d *differ) script(path string, a, b *Script) {
+	if a == nil || b == nil {
+		if a != b {
+			d.add(path, a, b)
+		}
+		return
+	}
+
+	d.hash(path+".code_hash", a.CodeHash, b.CodeHash)
+	if a.HashType != b.HashType {
+		d.add(path+".hash_type", a.HashType, b.HashType)
+	}
+	d.bytes(path+".args", a.Args, b.Args)
+}
+
+func (d *differ) outPoint(path string, a, b *OutPoint) {
+	d.hash(path+".tx_hash", a.TxHash, b.TxHash)
+	d.uint32(path+".index", a.Index, b.Index)
+}
+
+func (d *differ) length(path string, a, b int) int {
+	if a != b {
+		d.add(path+" length", a, b)
+	}
+
+	if a < b {
+		return a
+	}
+
+	return b
+}
+
+// DiffTransactions human readable differences between two transactions
+/*
+ * Each difference is reported as "path: a != b", e.g.
+ *
+ *     outputs[1].lock.args: 0x470d != 0xc832
+ *
+ * Values are compared normalized, so hex casing and leading zeros don't
+ * matter. Slices of different length are compared on common elements.
+ */
+func DiffTransactions(a, b *Transaction) []string {
+	d := &differ{diffs: make([]string, 0)}
+
+	d.uint32("version", a.Version, b.Version)
+
+	n := d.length("cell_deps", len(a.CellDeps), len(b.CellDeps))
+	for i := 0; i < n; i++ {
+		path := fmt.Sprintf("cell_deps[%d]", i)
+		d.outPoint(path+".out_point", &a.CellDeps[i].OutPoint, &b.CellDeps[i].OutPoint)
+		if a.CellDeps[i].DepType != b.CellDeps[i].DepType {
+			d.add(path+".dep_type", a.CellDeps[i].DepType, b.CellDeps[i].DepType)
+		}
+	}
+
+	n = d.length("header_deps", len(a.HeaderDeps), len(b.HeaderDeps))
+	for i := 0; i < n; i++ {
+		d.hash(fmt.Sprintf("header_deps[%d]", i), a.HeaderDeps[i], b.HeaderDeps[i])
+	}
+
+	n = d.length("inputs", len(a.Inputs), len(b.Inputs))
+	for i := 0; i < n; i++ {
+		path := fmt.Sprintf("inputs[%d]", i)
+		d.uint64(path+".since", a.Inputs[i].Since, b.Inputs[i].Since)
+		d.outPoint(path+".previous_output", &a.Inputs[i].PreviousOutput, &b.Inputs[i].PreviousOutput)
+	}
+
+	n = d.length("outputs", len(a.Outputs), len(b.Outputs))
+	for i := 0; i < n; i++ {
+		path := fmt.Sprintf("outputs[%d]", i)
+		d.uint64(path+".capacity", a.Outputs[i].Capacity, b.Outputs[i].Capacity)
+		d.script(path+".lock", &a.Outputs[i].Lock, &b.Outputs[i].Lock)
+		d.script(path+".type", a.Outputs[i].Type, b.Outputs[i].Type)
+	}
+
+	n = d.length("outputs_data", len(a.OutputsData), len(b.OutputsData))
+	for i := 0; i < n; i++ {
+		d.bytes(fmt.Sprintf("outputs_data[%d]", i), a.OutputsData[i], b.OutputsData[i])
+	}
+
+	n = d.length("witnesses", len(a.Witnesses), len(b.Witnesses))
+	for i := 0; i < n; i++ {
+		d.bytes(fmt.Sprintf("witnesses[%d]", i), a.Witnesses[i], b.Witnesses[i])
+	}
+
+	return d.diffs
+}
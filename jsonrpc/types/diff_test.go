@@ -0,0 +1,58 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffTransactions(t *testing.T) {
+	lock := Script{
+		CodeHash: "0x9bd7e06f3ecf4be0f2fcd2188b23f1b9fcc88e5d4b65a8637b17723bbda3cce8",
+		HashType: Type,
+		Args:     "0x470dcdc5e44064909650113a274b3b36aecb6dc7",
+	}
+
+	a := Transaction{
+		Version: "0x0",
+		Inputs: []CellInput{
+			{
+				Since: "0x0",
+				PreviousOutput: OutPoint{
+					TxHash: "0xee046ce2baeda575266d4164f394c53f66009f64759f7a9f12a014c692e79390",
+					Index:  "0x6",
+				},
+			},
+		},
+		Outputs:     []CellOutput{{Capacity: "0x1c6bf52634000", Lock: lock}},
+		OutputsData: []Bytes{"0x"},
+	}
+
+	// Same transaction, different hex casing and leading zeros
+	b := a
+	b.Version = "0x00"
+	b.Inputs = []CellInput{a.Inputs[0]}
+	b.Inputs[0].PreviousOutput.TxHash = "0xEE046CE2BAEDA575266D4164F394C53F66009F64759F7A9F12A014C692E79390"
+
+	if diffs := DiffTransactions(&a, &b); len(diffs) != 0 {
+		t.Errorf("expect no differences, got %v", diffs)
+		return
+	}
+
+	other := lock
+	other.Args = "0xc8328aabcd9b9e8e64fbc566c4385c3bdeb219d7"
+
+	b.Outputs = []CellOutput{{Capacity: "0x1c6bf52634000", Lock: other, Type: &lock}}
+	b.OutputsData = []Bytes{"0x", "0x"}
+
+	expect := []string{
+		"outputs[0].lock.args: 0x470dcdc5e44064909650113a274b3b36aecb6dc7 != 0xc8328aabcd9b9e8e64fbc566c4385c3bdeb219d7",
+		"outputs[0].type: <nil> != " + lock.String(),
+		"outputs_data length: 1 != 2",
+	}
+
+	got := DiffTransactions(&a, &b)
+	if !reflect.DeepEqual(expect, got) {
+		t.Errorf("mismatch result, expect %v, got %v", expect, got)
+		return
+	}
+}
@@ -0,0 +1,45 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FieldError error of a nested field, Path locates the failing field
+/*
+ * Paths of nested fields are joined by dot, and slice items by index,
+ * e.g. "outputs[2].lock.code_hash".
+ */
+type FieldError struct {
+	Path string
+	Err  error
+}
+
+// Error message prefixed with field path
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Err)
+}
+
+// Unwrap underlying error of the failing field
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// fieldError prefix error with field name
+func fieldError(name string, err error) error {
+	if fe, ok := err.(*FieldError); ok {
+		sep := "."
+		if strings.HasPrefix(fe.Path, "[") {
+			sep = ""
+		}
+
+		return &FieldError{Path: name + sep + fe.Path, Err: fe.Err}
+	}
+
+	return &FieldError{Path: name, Err: err}
+}
+
+// indexError prefix error with slice index
+func indexError(i int, err error) error {
+	return fieldError(fmt.Sprintf("[%d]", i), err)
+}
@@ -0,0 +1,116 @@
+package types
+
+import (
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"strings"
+)
+
+const ckbHashPersonalization = "ckb-default-hash"
+
+// NewCKBHasher create blake2b-256 hasher with ckb personalization
+func NewCKBHasher() hash.Hash {
+	return newBlake2b(32, ckbHashPersonalization)
+}
+
+// Hash output sizes of CKBHashN
+const (
+	ckbHashSize  = 32
+	blake160Size = 20
+)
+
+// CKBHashN ckb hash of data in size bytes, 32 for hash and 20 for blake160
+/*
+ * Blake160 is blake2b-256 truncated to 20 bytes rather than blake2b with
+ * 20 bytes output, the two differ as output size is a blake2b parameter.
+ */
+func CKBHashN(size int, data ...[]byte) ([]byte, error) {
+	if size != ckbHashSize && size != blake160Size {
+		return nil, fmt.Errorf("unsupported ckb hash size %d, should be 32 or 20", size)
+	}
+
+	h := NewCKBHasher()
+	for i := 0; i < len(data); i++ {
+		h.Write(data[i])
+	}
+
+	return h.Sum(nil)[:size], nil
+}
+
+// CKBHash blake2b-256 hash of data with ckb personalization
+func CKBHash(data ...[]byte) Hash {
+	// size is supported, never fails
+	h, _ := CKBHashN(ckbHashSize, data...)
+
+	return Hash("0x" + hex.EncodeToString(h))
+}
+
+// Blake160 first 20 bytes of ckb hash, e.g. pubkey hash of sighash lock args
+func Blake160(data ...[]byte) []byte {
+	// size is supported, never fails
+	h, _ := CKBHashN(blake160Size, data...)
+
+	return h
+}
+
+// EmptyDataHash ckb hash of empty data, the data hash of outputs without data
+const EmptyDataHash Hash = "0x44f4c69744d5f8c55d642062949dcae49bc4e7ef43d388c5a12f42b5633d163e"
+
+// DataHash ckb hash of cell data, EmptyDataHash for empty data
+func DataHash(data Bytes) (Hash, error) {
+	if data.IsEmpty() {
+		return EmptyDataHash, nil
+	}
+
+	raw, err := data.Raw()
+	if err != nil {
+		return "", err
+	}
+
+	return CKBHash(raw), nil
+}
+
+// ToHexUpper hash in uppercase hex with 0x prefix
+func (h Hash) ToHexUpper() string {
+	if !strings.HasPrefix(string(h), "0x") && !strings.HasPrefix(string(h), "0X") {
+		return strings.ToUpper(string(h))
+	}
+
+	return "0x" + strings.ToUpper(string(h)[2:])
+}
+
+// MarshalText marshal hash in lowercase hex
+func (h Hash) MarshalText() ([]byte, error) {
+	return []byte(strings.ToLower(string(h))), nil
+}
+
+// UpperHash hash marshalled in uppercase hex, for UIs expecting it
+/*
+ * Casing is chosen per value instead of by a package option, e.g.
+ *
+ *     json.Marshal(struct{ Hash UpperHash }{UpperHash(h)})
+ *
+ * Only affects MarshalText, thus JSON output, molecule bytes are binary
+ * and not affected. The 0x prefix is always lowercase.
+ */
+type UpperHash Hash
+
+// MarshalText marshal hash in uppercase hex
+func (h UpperHash) MarshalText() ([]byte, error) {
+	return []byte(Hash(h).ToHexUpper()), nil
+}
+
+// Byte32 convert hash to byte32, hash must be 32 bytes
+func (h Hash) Byte32() (Byte32, error) {
+	if _, err := h.Serialize(); err != nil {
+		return "", err
+	}
+
+	return Byte32(h), nil
+}
+
+// Hash convert byte32 to hash
+func (b Byte32) Hash() Hash {
+	return Hash(b)
+}
@@ -0,0 +1,139 @@
+package types
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+)
+
+func TestCKBHash(t *testing.T) {
+	expect := Hash("0x44f4c69744d5f8c55d642062949dcae49bc4e7ef43d388c5a12f42b5633d163e")
+
+	got := CKBHash()
+	if got != expect {
+		t.Errorf("mismatch empty hash, expect %v, got %v", expect, got)
+		return
+	}
+
+	got = CKBHash([]byte{})
+	if got != expect {
+		t.Errorf("mismatch empty hash, expect %v, got %v", expect, got)
+		return
+	}
+}
+
+func TestHashCasing(t *testing.T) {
+	h := Hash("0x9bd7e06f3ecf4be0f2fcd2188b23f1b9fcc88e5d4b65a8637b17723bbda3cce8")
+	upper := "0x9BD7E06F3ECF4BE0F2FCD2188B23F1B9FCC88E5D4B65A8637B17723BBDA3CCE8"
+
+	if h.ToHexUpper() != upper {
+		t.Errorf("mismatch result, expect %v, got %v", upper, h.ToHexUpper())
+		return
+	}
+
+	if Hash("0X"+upper[2:]).ToHexUpper() != upper {
+		t.Errorf("mismatch result, expect %v, got %v", upper, Hash("0X"+upper[2:]).ToHexUpper())
+		return
+	}
+
+	b, _ := json.Marshal(Hash(upper))
+	if string(b) != `"`+string(h)+`"` {
+		t.Errorf("mismatch result, expect %v, got %v", h, string(b))
+		return
+	}
+
+	b, _ = json.Marshal(UpperHash(h))
+	if string(b) != `"`+upper+`"` {
+		t.Errorf("mismatch result, expect %v, got %v", upper, string(b))
+		return
+	}
+
+	// Molecule bytes are the same in any casing
+	x, _ := h.Serialize()
+	u := Hash(upper)
+	y, _ := u.Serialize()
+	if string(x) != string(y) {
+		t.Errorf("mismatch serialized hash")
+		return
+	}
+}
+
+func TestHashByte32(t *testing.T) {
+	h := Hash("0x9bd7e06f3ecf4be0f2fcd2188b23f1b9fcc88e5d4b65a8637b17723bbda3cce8")
+
+	b, err := h.Byte32()
+	if err != nil {
+		t.Errorf("fail to convert hash: %s\n", err)
+		return
+	}
+
+	x, _ := h.Serialize()
+	y, err := b.Serialize()
+	if err != nil || string(x) != string(y) {
+		t.Errorf("mismatch serialized byte32, expect %x, got %x (%v)", x, y, err)
+		return
+	}
+
+	if b.Hash() != h {
+		t.Errorf("mismatch result, expect %v, got %v", h, b.Hash())
+		return
+	}
+
+	_, err = Hash("0x9bd7").Byte32()
+	if err == nil {
+		t.Errorf("expect error on short hash")
+		return
+	}
+}
+
+func TestDataHash(t *testing.T) {
+	if EmptyDataHash != CKBHash() {
+		t.Errorf("mismatch result, expect %v, got %v", CKBHash(), EmptyDataHash)
+		return
+	}
+
+	got, err := DataHash("0x")
+	if err != nil || got != EmptyDataHash {
+		t.Errorf("mismatch result, expect %v, got %v (%v)", EmptyDataHash, got, err)
+		return
+	}
+
+	got, err = DataHash("0x0000000000000000")
+	if err != nil || got != CKBHash(make([]byte, 8)) {
+		t.Errorf("mismatch result, expect %v, got %v (%v)", CKBHash(make([]byte, 8)), got, err)
+		return
+	}
+
+	_, err = DataHash("0x0")
+	if err == nil {
+		t.Errorf("expect error on invalid data")
+		return
+	}
+}
+
+func TestCKBHashN(t *testing.T) {
+	pub, _ := hex.DecodeString("03fe6c6d09d1a0f70255cddf25c5ed57d41b5c08822ae710dc10f8c88290e0acdf")
+
+	got := hex.EncodeToString(Blake160(pub))
+	if got != "c8328aabcd9b9e8e64fbc566c4385c3bdeb219d7" {
+		t.Errorf("mismatch result, expect %v, got %v", "c8328aabcd9b9e8e64fbc566c4385c3bdeb219d7", got)
+		return
+	}
+
+	full, err := CKBHashN(32, pub[:1], pub[1:])
+	if err != nil {
+		t.Errorf("fail to hash: %s\n", err)
+		return
+	}
+
+	if "0x"+hex.EncodeToString(full) != string(CKBHash(pub)) {
+		t.Errorf("mismatch result, expect %v, got %x", CKBHash(pub), full)
+		return
+	}
+
+	_, err = CKBHashN(64, pub)
+	if err == nil {
+		t.Errorf("expect error on unsupported size")
+		return
+	}
+}
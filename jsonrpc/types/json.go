@@ -0,0 +1,207 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// UnmarshalJSON unmarshal script hash type from string or number
+/*
+ * CKB RPC returns hash type as string, some tools emit the molecule
+ * number instead:
+ *
+ *     0 => data, 1 => type, 2 => data1
+ */
+func (t *ScriptHashType) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		*t = ScriptHashType(s)
+		return nil
+	}
+
+	var n uint8
+	if err := json.Unmarshal(b, &n); err != nil {
+		return fmt.Errorf("invalid script hash type %s", b)
+	}
+
+	switch n {
+	case 0:
+		*t = Data
+	case 1:
+		*t = Type
+	case 2:
+		*t = Data1
+	default:
+		return fmt.Errorf("invalid script hash type %d", n)
+	}
+
+	return nil
+}
+
+// UnmarshalJSON unmarshal cell output, null or absent type is nil
+/*
+ * The output is decoded from scratch, so a Type left over from a
+ * previous decode into the same value doesn't survive an absent type.
+ */
+func (o *CellOutput) UnmarshalJSON(b []byte) error {
+	type cellOutput CellOutput
+
+	var v cellOutput
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	*o = CellOutput(v)
+
+	return nil
+}
+
+// MarshalJSONCKB marshal cell output with capacity in decimal ckb, for display only
+/*
+ * The output is not RPC compatible, e.g. capacity 0x174876e800 is
+ * emitted as "1000". Lock and type are marshalled as usual.
+ */
+func (o *CellOutput) MarshalJSONCKB() ([]byte, error) {
+	c, err := o.Capacity.Uint64()
+	if err != nil {
+		return nil, fieldError("capacity", err)
+	}
+
+	return json.Marshal(struct {
+		Capacity string  `json:"capacity"`
+		Lock     Script  `json:"lock"`
+		Type     *Script `json:"type"`
+	}{
+		Capacity: ShannonToCKB(c),
+		Lock:     o.Lock,
+		Type:     o.Type,
+	})
+}
+
+// MarshalTransactionJSON marshal transaction with CKB RPC field order
+/*
+ * Fields are emitted in the same order as CKB RPC:
+ *
+ *     version, cell_deps, header_deps, inputs, outputs, outputs_data, witnesses
+ *
+ * Nil slices are emitted as empty arrays instead of null. It isn't a
+ * Transaction MarshalJSON, which would be promoted into structs embedding
+ * Transaction and drop their other fields.
+ */
+func MarshalTransactionJSON(t *Transaction) ([]byte, error) {
+	ordered := struct {
+		Version     Uint32       `json:"version"`
+		CellDeps    []CellDep    `json:"cell_deps"`
+		HeaderDeps  []Hash       `json:"header_deps"`
+		Inputs      []CellInput  `json:"inputs"`
+		Outputs     []CellOutput `json:"outputs"`
+		OutputsData []Bytes      `json:"outputs_data"`
+		Witnesses   []Bytes      `json:"witnesses"`
+	}{
+		Version:     t.Version,
+		CellDeps:    t.CellDeps,
+		HeaderDeps:  t.HeaderDeps,
+		Inputs:      t.Inputs,
+		Outputs:     t.Outputs,
+		OutputsData: t.OutputsData,
+		Witnesses:   t.Witnesses,
+	}
+
+	if ordered.CellDeps == nil {
+		ordered.CellDeps = []CellDep{}
+	}
+	if ordered.HeaderDeps == nil {
+		ordered.HeaderDeps = []Hash{}
+	}
+	if ordered.Inputs == nil {
+		ordered.Inputs = []CellInput{}
+	}
+	if ordered.Outputs == nil {
+		ordered.Outputs = []CellOutput{}
+	}
+	if ordered.OutputsData == nil {
+		ordered.OutputsData = []Bytes{}
+	}
+	if ordered.Witnesses == nil {
+		ordered.Witnesses = []Bytes{}
+	}
+
+	return json.Marshal(ordered)
+}
+
+// checkBytesItems check each item is 0x-prefix hex, error prefixed with index of failing item
+func checkBytesItems(items []Bytes) error {
+	for i := 0; i < len(items); i++ {
+		if _, err := items[i].Raw(); err != nil {
+			return indexError(i, err)
+		}
+	}
+
+	return nil
+}
+
+// ValidateBytes check outputs data and witnesses are 0x-prefix hex
+/*
+ * json.Unmarshal keeps malformed items as is, they would fail much later
+ * on serialize. Errors locate the item, e.g.
+ *
+ *     witnesses[1]: invalid bytes, should be 0x-prefix, use "0x" for empty bytes
+ */
+func (t *Transaction) ValidateBytes() error {
+	if err := checkBytesItems(t.OutputsData); err != nil {
+		return fieldError("outputs_data", err)
+	}
+
+	if err := checkBytesItems(t.Witnesses); err != nil {
+		return fieldError("witnesses", err)
+	}
+
+	return nil
+}
+
+// ParseTransactionJSON unmarshal transaction json and validate its outputs data and witnesses
+/*
+ * Validation is a pass after unmarshal rather than Transaction
+ * UnmarshalJSON, which would be promoted into structs embedding
+ * Transaction and drop their other fields, e.g. hash of rpc responses.
+ */
+func ParseTransactionJSON(data []byte) (*Transaction, error) {
+	var tx Transaction
+	if err := json.Unmarshal(data, &tx); err != nil {
+		return nil, err
+	}
+
+	if err := tx.ValidateBytes(); err != nil {
+		return nil, err
+	}
+
+	return &tx, nil
+}
+
+// ParseCkbCliTxFile parse transaction and witnesses from ckb-cli tx file
+/*
+ * ckb-cli tx files wrap the transaction with signing metadata:
+ *
+ *     {"transaction": {...}, "multisig_configs": {...}, "signatures": {...}}
+ *
+ * Only the transaction is parsed, the metadata is ignored.
+ */
+func ParseCkbCliTxFile(data []byte) (*Transaction, []Bytes, error) {
+	var file struct {
+		Transaction *Transaction `json:"transaction"`
+	}
+
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, nil, fmt.Errorf("invalid ckb-cli tx file: %s", err)
+	}
+
+	if file.Transaction == nil {
+		return nil, nil, fmt.Errorf("invalid ckb-cli tx file, missing transaction")
+	}
+
+	if err := file.Transaction.ValidateBytes(); err != nil {
+		return nil, nil, fieldError("transaction", err)
+	}
+
+	return file.Transaction, file.Transaction.Witnesses, nil
+}
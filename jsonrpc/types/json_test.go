@@ -0,0 +1,258 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestUnmarshalScriptHashType(t *testing.T) {
+	tests := []struct {
+		json   string
+		expect ScriptHashType
+	}{
+		{`"data"`, Data},
+		{`"type"`, Type},
+		{`"data1"`, Data1},
+		{`0`, Data},
+		{`1`, Type},
+		{`2`, Data1},
+	}
+
+	for _, test := range tests {
+		var got ScriptHashType
+
+		err := json.Unmarshal([]byte(test.json), &got)
+		if err != nil {
+			t.Errorf("fail to unmarshal %s: %s\n", test.json, err)
+			return
+		}
+
+		if got != test.expect {
+			t.Errorf("mismatch result, expect %v, got %v", test.expect, got)
+			return
+		}
+	}
+
+	var s Script
+
+	err := json.Unmarshal([]byte(`{"code_hash": "0x", "hash_type": 3, "args": "0x"}`), &s)
+	if err == nil {
+		t.Errorf("expect error on unknown numeric hash type")
+		return
+	}
+}
+
+func TestMarshalTransactionOrder(t *testing.T) {
+	tx := Transaction{
+		Version: "0x0",
+		HeaderDeps: []Hash{
+			"0xb815a396c5226009670e89ee514850dcde452bca746cdd6b41c104b50e559c70",
+		},
+		OutputsData: []Bytes{"0x1234"},
+	}
+
+	expect := `{"version":"0x0","cell_deps":[],"header_deps":["0xb815a396c5226009670e89ee514850dcde452bca746cdd6b41c104b50e559c70"],"inputs":[],"outputs":[],"outputs_data":["0x1234"],"witnesses":[]}`
+
+	got, err := MarshalTransactionJSON(&tx)
+	if err != nil {
+		t.Errorf("fail to marshal transaction: %s\n", err)
+		return
+	}
+
+	if string(got) != expect {
+		t.Errorf("mismatch result, expect %v, got %v", expect, string(got))
+		return
+	}
+
+	// Structs embedding Transaction keep their own fields
+	got, err = json.Marshal(struct {
+		Transaction
+		Hash Hash `json:"hash"`
+	}{tx, "0x01"})
+	if err != nil {
+		t.Errorf("fail to marshal transaction: %s\n", err)
+		return
+	}
+
+	if !strings.Contains(string(got), `"hash":"0x01"`) {
+		t.Errorf("expect hash field, got %v", string(got))
+		return
+	}
+}
+
+func TestUnmarshalCellOutputType(t *testing.T) {
+	lock := `"lock": {
+		"code_hash": "0x9bd7e06f3ecf4be0f2fcd2188b23f1b9fcc88e5d4b65a8637b17723bbda3cce8",
+		"hash_type": "type",
+		"args": "0xc8328aabcd9b9e8e64fbc566c4385c3bdeb219d7"
+	}`
+
+	tests := []struct {
+		json    string
+		hasType bool
+	}{
+		{`{"capacity": "0x0", ` + lock + `, "type": null}`, false},
+		{`{"capacity": "0x0", ` + lock + `}`, false},
+		{`{"capacity": "0x0", ` + lock + `, "type": {
+			"code_hash": "0x82d76d1b75fe2fd9a27dfbaa65a039221a380d76c926f378d3f81cf3e7e13f2e",
+			"hash_type": "type",
+			"args": "0x"
+		}}`, true},
+	}
+
+	for _, test := range tests {
+		// Start from an output with type, it must not leak into the result
+		got := CellOutput{Type: &Script{CodeHash: "0x00", HashType: Data, Args: "0x"}}
+
+		err := json.Unmarshal([]byte(test.json), &got)
+		if err != nil {
+			t.Errorf("fail to unmarshal %s: %s\n", test.json, err)
+			return
+		}
+
+		if (got.Type != nil) != test.hasType {
+			t.Errorf("mismatch type, expect present %v, got %v", test.hasType, got.Type)
+			return
+		}
+
+		if test.hasType && got.Type.CodeHash != "0x82d76d1b75fe2fd9a27dfbaa65a039221a380d76c926f378d3f81cf3e7e13f2e" {
+			t.Errorf("mismatch type code hash, got %v", got.Type.CodeHash)
+			return
+		}
+
+		if got.Lock.Args != "0xc8328aabcd9b9e8e64fbc566c4385c3bdeb219d7" {
+			t.Errorf("mismatch lock args, got %v", got.Lock.Args)
+			return
+		}
+	}
+}
+
+func TestParseCkbCliTxFile(t *testing.T) {
+	data, err := ioutil.ReadFile(filepath.Join("testdata", "ckb_cli_tx.json"))
+	if err != nil {
+		t.Errorf("fail to read tx file: %s\n", err)
+		return
+	}
+
+	tx, witnesses, err := ParseCkbCliTxFile(data)
+	if err != nil {
+		t.Errorf("fail to parse tx file: %s\n", err)
+		return
+	}
+
+	if len(witnesses) != 1 || len(tx.Outputs) != 2 {
+		t.Errorf("unexpected parsed transaction %v", tx.Summary())
+		return
+	}
+
+	expect := Hash("0x99d6e242e482489434853da49922e3a24cb40212e1faf1715f82df9cea9a72e1")
+
+	h, err := tx.Hash()
+	if err != nil || h != expect {
+		t.Errorf("mismatch hash, expect %v, got %v (%v)", expect, h, err)
+		return
+	}
+
+	_, _, err = ParseCkbCliTxFile([]byte(`{"signatures": {}}`))
+	if err == nil {
+		t.Errorf("expect error on missing transaction")
+		return
+	}
+}
+
+func TestMarshalCellOutputCKB(t *testing.T) {
+	o := CellOutput{
+		Capacity: "0x174876e801",
+		Lock: Script{
+			CodeHash: "0x9bd7e06f3ecf4be0f2fcd2188b23f1b9fcc88e5d4b65a8637b17723bbda3cce8",
+			HashType: Type,
+			Args:     "0xc8328aabcd9b9e8e64fbc566c4385c3bdeb219d7",
+		},
+	}
+
+	got, err := o.MarshalJSONCKB()
+	if err != nil {
+		t.Errorf("fail to marshal cell output: %s\n", err)
+		return
+	}
+
+	expect := `{"capacity":"1000.00000001","lock":{"code_hash":"0x9bd7e06f3ecf4be0f2fcd2188b23f1b9fcc88e5d4b65a8637b17723bbda3cce8","hash_type":"type","args":"0xc8328aabcd9b9e8e64fbc566c4385c3bdeb219d7"},"type":null}`
+	if string(got) != expect {
+		t.Errorf("mismatch result, expect %v, got %v", expect, string(got))
+		return
+	}
+
+	// default marshal stays rpc compatible
+	got, err = json.Marshal(&o)
+	if err != nil || !strings.HasPrefix(string(got), `{"capacity":"0x174876e801"`) {
+		t.Errorf("mismatch result, expect rpc capacity, got %s (%v)", got, err)
+		return
+	}
+
+	o.Capacity = "1000"
+	_, err = o.MarshalJSONCKB()
+	if err == nil {
+		t.Errorf("expect error on invalid capacity")
+		return
+	}
+}
+
+func TestParseTransactionJSON(t *testing.T) {
+	tests := []struct {
+		outputsData string
+		witnesses   string
+		invalid     string
+	}{
+		{`["0x", "0x1234"]`, `["0x", "0xabcdef"]`, ""},
+		{`["0x", "1234"]`, `["0x"]`, "outputs_data[1]: "},
+		{`["0x"]`, `["0x55", "0x12", "0xzz"]`, "witnesses[2]: "},
+		{`["0x123", "0x"]`, `[]`, "outputs_data[0]: "},
+		{`["0x", 12]`, `[]`, "outputs_data"},
+	}
+
+	for _, test := range tests {
+		tx, err := ParseTransactionJSON([]byte(`{"version": "0x0", "outputs_data": ` + test.outputsData + `, "witnesses": ` + test.witnesses + `}`))
+
+		if test.invalid == "" {
+			if err != nil {
+				t.Errorf("fail to parse %s %s: %s\n", test.outputsData, test.witnesses, err)
+				return
+			}
+
+			if len(tx.OutputsData) != 2 || tx.OutputsData[1] != "0x1234" || len(tx.Witnesses) != 2 || tx.Witnesses[1] != "0xabcdef" {
+				t.Errorf("mismatch result, got %v %v", tx.OutputsData, tx.Witnesses)
+				return
+			}
+			continue
+		}
+
+		if err == nil {
+			t.Errorf("expect error on invalid bytes %s %s", test.outputsData, test.witnesses)
+			return
+		}
+
+		if !strings.Contains(err.Error(), test.invalid) {
+			t.Errorf("mismatch error, expect %v in %v", test.invalid, err)
+			return
+		}
+	}
+
+	// Plain json.Unmarshal keeps malformed items, ValidateBytes locates them
+	var tx Transaction
+	err := json.Unmarshal([]byte(`{"version": "0x0", "outputs_data": ["0x"], "witnesses": ["0x", "abcd"]}`), &tx)
+	if err != nil {
+		t.Errorf("fail to unmarshal: %s\n", err)
+		return
+	}
+
+	err = tx.ValidateBytes()
+	var fe *FieldError
+	if !errors.As(err, &fe) || fe.Path != "witnesses[1]" {
+		t.Errorf("mismatch error path, expect witnesses[1], got %v", err)
+		return
+	}
+}
@@ -0,0 +1,102 @@
+package types
+
+import (
+	"encoding/hex"
+	"fmt"
+)
+
+// merkleMerge parent node of left and right, ckb hash of both concatenated
+func merkleMerge(left, right []byte) []byte {
+	h := NewCKBHasher()
+	h.Write(left)
+	h.Write(right)
+
+	return h.Sum(nil)
+}
+
+// cbmtRoot root of complete binary merkle tree, zero hash if no leaves
+/*
+ * The tree of n leaves is stored in an array of 2n - 1 nodes, leaves
+ * take the last n nodes in order, and node i is the parent of nodes
+ * 2i + 1 and 2i + 2. The root is node 0.
+ */
+func cbmtRoot(leaves [][]byte) []byte {
+	if len(leaves) == 0 {
+		return make([]byte, hashSize)
+	}
+
+	n := len(leaves)
+	nodes := make([][]byte, 2*n-1)
+	copy(nodes[n-1:], leaves)
+
+	for i := n - 2; i >= 0; i-- {
+		nodes[i] = merkleMerge(nodes[2*i+1], nodes[2*i+2])
+	}
+
+	return nodes[0]
+}
+
+// hashesToLeaves decode hashes into merkle leaves
+func hashesToLeaves(hashes []Hash) ([][]byte, error) {
+	leaves := make([][]byte, len(hashes))
+	for i := 0; i < len(hashes); i++ {
+		b, err := hashes[i].Serialize()
+		if err != nil {
+			return nil, indexError(i, err)
+		}
+
+		leaves[i] = b
+	}
+
+	return leaves, nil
+}
+
+// TransactionsMerkleRoot CBMT root of transaction hashes
+/*
+ * This is the raw transactions root, the header transactions_root also
+ * commits to witnesses, see TransactionsRoot.
+ */
+func TransactionsMerkleRoot(txHashes []Hash) (Hash, error) {
+	leaves, err := hashesToLeaves(txHashes)
+	if err != nil {
+		return "", err
+	}
+
+	return Hash("0x" + hex.EncodeToString(cbmtRoot(leaves))), nil
+}
+
+// TransactionsRoot header transactions root of transaction and witness hashes
+/*
+ *     transactions_root = merge(CBMT(tx hashes), CBMT(witness hashes))
+ *
+ * Witness hashes are ckb hashes of the full serialized transactions.
+ */
+func TransactionsRoot(txHashes []Hash, witnessHashes []Hash) (Hash, error) {
+	if len(txHashes) != len(witnessHashes) {
+		return "", fmt.Errorf("tx hashes and witness hashes length mismatch, %d != %d", len(txHashes), len(witnessHashes))
+	}
+
+	txLeaves, err := hashesToLeaves(txHashes)
+	if err != nil {
+		return "", fieldError("tx_hashes", err)
+	}
+
+	witnessLeaves, err := hashesToLeaves(witnessHashes)
+	if err != nil {
+		return "", fieldError("witness_hashes", err)
+	}
+
+	root := merkleMerge(cbmtRoot(txLeaves), cbmtRoot(witnessLeaves))
+
+	return Hash("0x" + hex.EncodeToString(root)), nil
+}
+
+// WitnessHash ckb hash of the full serialized transaction, with witnesses
+func (t *Transaction) WitnessHash() (Hash, error) {
+	b, err := t.FullSerialize()
+	if err != nil {
+		return "", err
+	}
+
+	return CKBHash(b), nil
+}
@@ -0,0 +1,107 @@
+package types
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+// Leaves are ckb hashes of single bytes 0..4, not hashes of a real block.
+// Expected roots were computed with a separate CBMT implementation, real
+// blocks are checked by the opt-in ckbvectors block test.
+func TestTransactionsMerkleRoot(t *testing.T) {
+	leaves := []Hash{
+		"0xef3f1252fe6f373c05e5e9c6371e230e29c1226b21752ab21611479b57a0f9d6",
+		"0xb9aaddf96f7f5c742950611835c040af6b7024adf1148cda2acb087f0129befb",
+		"0x10ad3f5012ce514f409e4da4c011c24a314434881872c124ae064012afaf389d",
+		"0xf37dfa5b009ea001acd3617886d9efecf31bb153bee72b7fb73f9e03ff3d5a34",
+		"0x97bff01bcad316a4b534ef221bd66da97018df9058d313dee1fa4455bf41332e",
+	}
+
+	cases := []struct {
+		count  int
+		expect Hash
+	}{
+		{0, "0x0000000000000000000000000000000000000000000000000000000000000000"},
+		{1, "0xef3f1252fe6f373c05e5e9c6371e230e29c1226b21752ab21611479b57a0f9d6"},
+		{2, "0xd28313a792777214fc8a5ea25e4fdb2e29efbf39bb4a42b49777c579b72611af"},
+		{3, "0xee6ff2158389efea3eac6040465a87dd0771854d1437a996530c7ed43f86889c"},
+		{5, "0x47bda231b73a389d5e487dd23b4d672f2243bfefd9125dd951ae68d1d4e6df91"},
+	}
+
+	for _, c := range cases {
+		got, err := TransactionsMerkleRoot(leaves[:c.count])
+		if err != nil {
+			t.Errorf("fail to compute merkle root: %s\n", err)
+			return
+		}
+
+		if got != c.expect {
+			t.Errorf("mismatch result of %d leaves, expect %v, got %v", c.count, c.expect, got)
+			return
+		}
+	}
+
+	_, err := TransactionsMerkleRoot([]Hash{"0x00"})
+	if err == nil {
+		t.Errorf("expect error on invalid hash")
+		return
+	}
+}
+
+func TestTransactionsRoot(t *testing.T) {
+	txHashes := []Hash{
+		"0xef3f1252fe6f373c05e5e9c6371e230e29c1226b21752ab21611479b57a0f9d6",
+		"0xb9aaddf96f7f5c742950611835c040af6b7024adf1148cda2acb087f0129befb",
+		"0x10ad3f5012ce514f409e4da4c011c24a314434881872c124ae064012afaf389d",
+	}
+	witnessHashes := []Hash{
+		"0xf37dfa5b009ea001acd3617886d9efecf31bb153bee72b7fb73f9e03ff3d5a34",
+		"0x97bff01bcad316a4b534ef221bd66da97018df9058d313dee1fa4455bf41332e",
+		"0xef3f1252fe6f373c05e5e9c6371e230e29c1226b21752ab21611479b57a0f9d6",
+	}
+
+	got, err := TransactionsRoot(txHashes, witnessHashes)
+	if err != nil {
+		t.Errorf("fail to compute transactions root: %s\n", err)
+		return
+	}
+
+	expect := Hash("0x1ff716b853e158167baa935201ca0c5696bc9c42e413fdfddad797b7664e11f6")
+	if got != expect {
+		t.Errorf("mismatch result, expect %v, got %v", expect, got)
+		return
+	}
+
+	_, err = TransactionsRoot(txHashes, witnessHashes[:2])
+	if err == nil {
+		t.Errorf("expect error on length mismatch")
+		return
+	}
+}
+
+func TestWitnessHash(t *testing.T) {
+	b, err := ioutil.ReadFile(filepath.Join("testdata", "corpus", "full_transaction", "dao_deposit.mol"))
+	if err != nil {
+		t.Errorf("fail to read corpus: %s\n", err)
+		return
+	}
+
+	tx, _, err := DeserializeFullTransaction(b)
+	if err != nil {
+		t.Errorf("fail to deserialize transaction: %s\n", err)
+		return
+	}
+
+	got, err := tx.WitnessHash()
+	if err != nil {
+		t.Errorf("fail to compute witness hash: %s\n", err)
+		return
+	}
+
+	expect := Hash("0x40ec5f8a180e8a445dadbe31e91ea3bb42c3b0a3f61bc5fd1fd6e2a26efc8a44")
+	if got != expect {
+		t.Errorf("mismatch result, expect %v, got %v", expect, got)
+		return
+	}
+}
@@ -0,0 +1,211 @@
+package types
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+)
+
+// PartialTransaction partially signed transaction exchanged between multisig signers
+/*
+ * Signatures collects signatures of each lock group, keyed by the lock
+ * script hash of the group. Witnesses is the only source of witnesses,
+ * Transaction.Witnesses must be empty.
+ */
+type PartialTransaction struct {
+	Transaction Transaction
+	Witnesses   []Bytes
+	Signatures  map[Hash][]Bytes
+}
+
+// sortedSignatureHashes lock hashes of signatures in byte order
+func (p *PartialTransaction) sortedSignatureHashes() ([]Hash, [][]byte, error) {
+	hashes := make([]Hash, 0, len(p.Signatures))
+	for h := range p.Signatures {
+		hashes = append(hashes, h)
+	}
+
+	raws := make(map[Hash][]byte, len(hashes))
+	for _, h := range hashes {
+		b, err := h.Serialize()
+		if err != nil {
+			return nil, nil, fieldError(fmt.Sprintf("signatures[%s]", h), err)
+		}
+
+		raws[h] = b
+	}
+
+	sort.Slice(hashes, func(i, j int) bool {
+		return bytes.Compare(raws[hashes[i]], raws[hashes[j]]) < 0
+	})
+
+	// Keys differing only in hex casing are the same lock hash
+	for i := 1; i < len(hashes); i++ {
+		if bytes.Equal(raws[hashes[i-1]], raws[hashes[i]]) {
+			return nil, nil, fmt.Errorf("invalid partial transaction, duplicate signature group %s", hashes[i])
+		}
+	}
+
+	ret := make([][]byte, len(hashes))
+	for i, h := range hashes {
+		ret[i] = raws[h]
+	}
+
+	return hashes, ret, nil
+}
+
+// Serialize partial transaction
+/*
+ * Molecule schema:
+ *
+ *     table SignatureGroup {
+ *         lock_hash:  Byte32,
+ *         signatures: BytesVec,
+ *     }
+ *
+ *     vector SignatureGroupVec <SignatureGroup>;
+ *
+ *     table PartialTransaction {
+ *         raw:        RawTransaction,
+ *         witnesses:  BytesVec,
+ *         signatures: SignatureGroupVec,
+ *     }
+ *
+ * Signature groups are ordered by lock hash, so equal partial
+ * transactions serialize to equal bytes.
+ */
+func (p *PartialTransaction) Serialize() ([]byte, error) {
+	if len(p.Transaction.Witnesses) != 0 {
+		return nil, fmt.Errorf("invalid partial transaction, transaction witnesses must be empty, use Witnesses")
+	}
+
+	raw, err := p.Transaction.Serialize()
+	if err != nil {
+		return nil, fieldError("raw", err)
+	}
+
+	ws, err := SerializeDynVecOf(p.Witnesses)
+	if err != nil {
+		return nil, fieldError("witnesses", err)
+	}
+
+	hashes, lockHashes, err := p.sortedSignatureHashes()
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make([][]byte, len(hashes))
+	for i, h := range hashes {
+		sigs, err := SerializeDynVecOf(p.Signatures[h])
+		if err != nil {
+			return nil, fieldError(fmt.Sprintf("signatures[%s]", h), err)
+		}
+
+		groups[i], err = buildTable([][]byte{lockHashes[i], sigs})
+		if err != nil {
+			return nil, fieldError(fmt.Sprintf("signatures[%s]", h), err)
+		}
+	}
+
+	gs, err := SerializeDynVec(groups)
+	if err != nil {
+		return nil, fieldError("signatures", err)
+	}
+
+	return buildTable([][]byte{raw, ws, gs})
+}
+
+// decodeBytesVecAt decode bytes vector at offset
+func decodeBytesVecAt(b []byte, off int) ([]Bytes, int, error) {
+	items, size, err := decodeDynVecAt(b, off)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ret := make([]Bytes, len(items))
+	for i := 0; i < len(items); i++ {
+		v, n, err := decodeBytesAt(b[:items[i].end], items[i].start)
+		if err == nil {
+			err = checkSpan(items[i], n)
+		}
+		if err != nil {
+			return nil, 0, indexError(i, err)
+		}
+
+		ret[i] = v
+	}
+
+	return ret, size, nil
+}
+
+// decodePartialTransactionAt decode partial transaction at offset
+func decodePartialTransactionAt(b []byte, off int) (*PartialTransaction, int, error) {
+	fields, size, err := decodeTableAt(b, off, 3)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	tx, n, err := decodeTransactionAt(b[:fields[0].end], fields[0].start)
+	if err == nil {
+		err = checkSpan(fields[0], n)
+	}
+	if err != nil {
+		return nil, 0, fieldError("raw", err)
+	}
+
+	ws, n, err := decodeBytesVecAt(b[:fields[1].end], fields[1].start)
+	if err == nil {
+		err = checkSpan(fields[1], n)
+	}
+	if err != nil {
+		return nil, 0, fieldError("witnesses", err)
+	}
+
+	groups, n, err := decodeDynVecAt(b[:fields[2].end], fields[2].start)
+	if err == nil {
+		err = checkSpan(fields[2], n)
+	}
+	if err != nil {
+		return nil, 0, fieldError("signatures", err)
+	}
+
+	signatures := make(map[Hash][]Bytes, len(groups))
+	for i := 0; i < len(groups); i++ {
+		g, n, err := decodeTableAt(b[:groups[i].end], groups[i].start, 2)
+		if err == nil {
+			err = checkSpan(groups[i], n)
+		}
+		if err != nil {
+			return nil, 0, fieldError("signatures", indexError(i, err))
+		}
+
+		h, n, err := decodeHashAt(b[:g[0].end], g[0].start)
+		if err == nil {
+			err = checkSpan(g[0], n)
+		}
+		if err != nil {
+			return nil, 0, fieldError("signatures", indexError(i, fieldError("lock_hash", err)))
+		}
+
+		if _, ok := signatures[h]; ok {
+			return nil, 0, fmt.Errorf("invalid partial transaction, duplicate signature group %s", h)
+		}
+
+		sigs, n, err := decodeBytesVecAt(b[:g[1].end], g[1].start)
+		if err == nil {
+			err = checkSpan(g[1], n)
+		}
+		if err != nil {
+			return nil, 0, fieldError("signatures", indexError(i, fieldError("signatures", err)))
+		}
+
+		signatures[h] = sigs
+	}
+
+	return &PartialTransaction{Transaction: *tx, Witnesses: ws, Signatures: signatures}, size, nil
+}
+
+// DeserializePartialTransaction deserialize partial transaction
+func DeserializePartialTransaction(b []byte) (*PartialTransaction, error) {
+	return strictDecode(b, decodePartialTransactionAt)
+}
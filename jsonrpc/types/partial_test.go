@@ -0,0 +1,118 @@
+package types
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestPartialTransactionSerialize(t *testing.T) {
+	raw, err := ioutil.ReadFile(filepath.Join("testdata", "corpus", "transaction", "dao_deposit.mol"))
+	if err != nil {
+		t.Errorf("fail to read transaction: %s\n", err)
+		return
+	}
+
+	tx, err := DeserializeTransaction(raw)
+	if err != nil {
+		t.Errorf("fail to deserialize transaction: %s\n", err)
+		return
+	}
+
+	p := PartialTransaction{
+		Transaction: *tx,
+		Witnesses:   []Bytes{"0x", "0x1234"},
+		Signatures: map[Hash][]Bytes{
+			"0xc219351b150b900e50a7039f1e448b844110927e5fd9bd30425806cb8ddff1fd": {},
+			"0x32e555f3ff8e135cece1351a6a2971518392c1e30375c1e006ad0ce8eac07947": {
+				"0x1111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111",
+				"0x2222222222222222222222222222222222222222222222222222222222222222222222222222222222222222222222222222222222222222222222222222222222",
+			},
+		},
+	}
+
+	b, err := p.Serialize()
+	if err != nil {
+		t.Errorf("fail to serialize partial transaction: %s\n", err)
+		return
+	}
+
+	// Signature groups sorted by lock hash regardless of map order
+	expect := Hash("0x2a5dc5ab7ed4299f238ce7b650562c88293e78c1d7ac432c4fb59ec40a8660ff")
+	if CKBHash(b) != expect {
+		t.Errorf("mismatch result, expect %v, got %v", expect, CKBHash(b))
+		return
+	}
+
+	d, err := DeserializePartialTransaction(b)
+	if err != nil {
+		t.Errorf("fail to deserialize partial transaction: %s\n", err)
+		return
+	}
+
+	if !reflect.DeepEqual(*d, p) {
+		t.Errorf("mismatch result, expect %v, got %v", p, *d)
+		return
+	}
+
+	_, err = DeserializePartialTransaction(b[:len(b)-1])
+	if err == nil {
+		t.Errorf("expect error on truncated partial transaction")
+		return
+	}
+
+	_, err = DeserializePartialTransaction(append(b, 0))
+	if err == nil {
+		t.Errorf("expect error on trailing bytes")
+		return
+	}
+}
+
+func TestPartialTransactionSignatureCasing(t *testing.T) {
+	lower := Hash("0x32e555f3ff8e135cece1351a6a2971518392c1e30375c1e006ad0ce8eac07947")
+	upper := Hash(lower.ToHexUpper())
+	sig := Bytes("0x1111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111")
+
+	p := PartialTransaction{
+		Transaction: *NewTransaction(),
+		Witnesses:   []Bytes{},
+		Signatures:  map[Hash][]Bytes{upper: {sig}},
+	}
+
+	b, err := p.Serialize()
+	if err != nil {
+		t.Errorf("fail to serialize partial transaction: %s\n", err)
+		return
+	}
+
+	// Uppercase key round trips as the same lock hash
+	d, err := DeserializePartialTransaction(b)
+	if err != nil {
+		t.Errorf("fail to deserialize partial transaction: %s\n", err)
+		return
+	}
+
+	if len(d.Signatures) != 1 || !reflect.DeepEqual(d.Signatures[lower], []Bytes{sig}) {
+		t.Errorf("mismatch result, expect %v, got %v", p.Signatures, d.Signatures)
+		return
+	}
+
+	// Keys differing only in casing collide
+	p.Signatures[lower] = []Bytes{sig}
+
+	_, err = p.Serialize()
+	if err == nil {
+		t.Errorf("expect error on duplicate signature group")
+		return
+	}
+
+	delete(p.Signatures, lower)
+	p.Transaction.Witnesses = []Bytes{"0x"}
+
+	_, err = p.Serialize()
+	if err == nil {
+		t.Errorf("expect error on transaction witnesses")
+		return
+	}
+}
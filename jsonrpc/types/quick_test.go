@@ -0,0 +1,171 @@
+package types
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+)
+
+// Generators of random valid values in canonical form, as deserializers
+// produce them: lowercase hex, minimal hex numbers and non-nil slices.
+
+func randRaw(r *rand.Rand, size int) []byte {
+	b := make([]byte, size)
+	r.Read(b)
+
+	return b
+}
+
+func randHash(r *rand.Rand) Hash {
+	return Hash(BytesFromRaw(randRaw(r, 32)))
+}
+
+func randBytes(r *rand.Rand, size int) Bytes {
+	return BytesFromRaw(randRaw(r, r.Intn(size+1)))
+}
+
+func randScript(r *rand.Rand, size int) Script {
+	hashTypes := []ScriptHashType{Data, Type, Data1}
+
+	return Script{
+		CodeHash: randHash(r),
+		HashType: hashTypes[r.Intn(len(hashTypes))],
+		Args:     randBytes(r, size),
+	}
+}
+
+func randOutPoint(r *rand.Rand) OutPoint {
+	return OutPoint{TxHash: randHash(r), Index: newUint32(r.Uint32())}
+}
+
+func randCellInput(r *rand.Rand) CellInput {
+	return CellInput{Since: newUint64(r.Uint64()), PreviousOutput: randOutPoint(r)}
+}
+
+func randCellOutput(r *rand.Rand, size int) CellOutput {
+	o := CellOutput{Capacity: newUint64(r.Uint64()), Lock: randScript(r, size)}
+	if r.Intn(2) == 0 {
+		s := randScript(r, size)
+		o.Type = &s
+	}
+
+	return o
+}
+
+func randTransaction(r *rand.Rand, size int) Transaction {
+	depTypes := []DepType{Code, DepGroup}
+
+	t := Transaction{
+		Version:    newUint32(r.Uint32()),
+		CellDeps:   make([]CellDep, r.Intn(size+1)),
+		HeaderDeps: make([]Hash, r.Intn(size+1)),
+		Inputs:     make([]CellInput, r.Intn(size+1)),
+		Outputs:    make([]CellOutput, r.Intn(size+1)),
+		Witnesses:  make([]Bytes, r.Intn(size+1)),
+	}
+
+	for i := range t.CellDeps {
+		t.CellDeps[i] = CellDep{OutPoint: randOutPoint(r), DepType: depTypes[r.Intn(len(depTypes))]}
+	}
+	for i := range t.HeaderDeps {
+		t.HeaderDeps[i] = randHash(r)
+	}
+	for i := range t.Inputs {
+		t.Inputs[i] = randCellInput(r)
+	}
+
+	t.OutputsData = make([]Bytes, len(t.Outputs))
+	for i := range t.Outputs {
+		t.Outputs[i] = randCellOutput(r, size)
+		t.OutputsData[i] = randBytes(r, size)
+	}
+	for i := range t.Witnesses {
+		t.Witnesses[i] = randBytes(r, size)
+	}
+
+	return t
+}
+
+type quickScript struct{ Script }
+type quickOutPoint struct{ OutPoint }
+type quickCellInput struct{ CellInput }
+type quickCellOutput struct{ CellOutput }
+type quickTransaction struct{ Transaction }
+
+func (quickScript) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(quickScript{randScript(r, size)})
+}
+
+func (quickOutPoint) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(quickOutPoint{randOutPoint(r)})
+}
+
+func (quickCellInput) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(quickCellInput{randCellInput(r)})
+}
+
+func (quickCellOutput) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(quickCellOutput{randCellOutput(r, size)})
+}
+
+func (quickTransaction) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(quickTransaction{randTransaction(r, size)})
+}
+
+func TestQuickRoundTrip(t *testing.T) {
+	checks := map[string]interface{}{
+		"script": func(q quickScript) bool {
+			b, err := q.Serialize()
+			if err != nil {
+				return false
+			}
+
+			got, err := DeserializeScript(b)
+			return err == nil && reflect.DeepEqual(&q.Script, got)
+		},
+		"outpoint": func(q quickOutPoint) bool {
+			b, err := q.Serialize()
+			if err != nil {
+				return false
+			}
+
+			got, err := DeserializeOutPoint(b)
+			return err == nil && reflect.DeepEqual(&q.OutPoint, got)
+		},
+		"cell input": func(q quickCellInput) bool {
+			b, err := q.Serialize()
+			if err != nil {
+				return false
+			}
+
+			got, err := DeserializeCellInput(b)
+			return err == nil && reflect.DeepEqual(&q.CellInput, got)
+		},
+		"cell output": func(q quickCellOutput) bool {
+			b, err := q.Serialize()
+			if err != nil {
+				return false
+			}
+
+			got, err := DeserializeCellOutput(b)
+			return err == nil && reflect.DeepEqual(&q.CellOutput, got)
+		},
+		"transaction": func(q quickTransaction) bool {
+			b, err := q.FullSerialize()
+			if err != nil {
+				return false
+			}
+
+			got, _, err := DeserializeFullTransaction(b)
+			return err == nil && reflect.DeepEqual(&q.Transaction, got)
+		},
+	}
+
+	for name, f := range checks {
+		if err := quick.Check(f, &quick.Config{MaxCount: 200}); err != nil {
+			t.Errorf("fail to round trip %s: %s\n", name, err)
+			return
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package types
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+)
+
+// SetArgs replace script args with raw bytes
+func (s *Script) SetArgs(raw []byte) {
+	s.Args = BytesFromRaw(raw)
+}
+
+// AppendArgs append raw bytes to script args
+func (s *Script) AppendArgs(raw []byte) error {
+	args, err := s.Args.Raw()
+	if err != nil {
+		return err
+	}
+
+	s.SetArgs(append(args, raw...))
+
+	return nil
+}
+
+// Hash script hash, blake2b-256 of the serialized script
+func (s *Script) Hash() (Hash, error) {
+	b, err := s.Serialize()
+	if err != nil {
+		return "", err
+	}
+
+	return CKBHash(b), nil
+}
+
+// HashEquals whether script hash matches lockHash, hex casing doesn't matter
+/*
+ * A script can't be recovered from its hash, blake2b is one way. Given
+ * candidate scripts, e.g. known locks of a wallet, match them against
+ * the hash instead.
+ */
+func (s *Script) HashEquals(lockHash Hash) (bool, error) {
+	expect, err := lockHash.Serialize()
+	if err != nil {
+		return false, err
+	}
+
+	h, err := s.Hash()
+	if err != nil {
+		return false, err
+	}
+
+	got, err := h.Serialize()
+	if err != nil {
+		return false, err
+	}
+
+	return bytes.Equal(got, expect), nil
+}
+
+// ParseArgsFields split args into fields of lengths, remaining bytes as a final field
+/*
+ * Lengths summing to the args length yield exactly len(lengths) fields,
+ * shorter lengths yield an extra variable length field with the rest.
+ */
+func ParseArgsFields(args Bytes, lengths ...int) ([]Bytes, error) {
+	b, err := args.Raw()
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make([]Bytes, 0, len(lengths)+1)
+	offset := 0
+	for i, l := range lengths {
+		if l < 0 {
+			return nil, fmt.Errorf("invalid args field %d length %d", i, l)
+		}
+
+		if l > len(b)-offset {
+			return nil, fmt.Errorf("invalid args, field %d needs %d bytes, %d left", i, l, len(b)-offset)
+		}
+
+		fields = append(fields, BytesFromRaw(b[offset:offset+l]))
+		offset += l
+	}
+
+	if offset < len(b) {
+		fields = append(fields, BytesFromRaw(b[offset:]))
+	}
+
+	return fields, nil
+}
+
+// SortScriptsByHash stable sort scripts by script hash bytes
+func SortScriptsByHash(scripts []*Script) error {
+	hashes := make(map[*Script][]byte, len(scripts))
+	for i := 0; i < len(scripts); i++ {
+		h, err := scripts[i].Hash()
+		if err != nil {
+			return indexError(i, err)
+		}
+
+		// Hash is always 32 bytes hex
+		b, _ := h.Serialize()
+		hashes[scripts[i]] = b
+	}
+
+	sort.SliceStable(scripts, func(i, j int) bool {
+		return bytes.Compare(hashes[scripts[i]], hashes[scripts[j]]) < 0
+	})
+
+	return nil
+}
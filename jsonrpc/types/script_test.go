@@ -0,0 +1,175 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestScriptArgs(t *testing.T) {
+	s := Script{
+		CodeHash: "0x9bd7e06f3ecf4be0f2fcd2188b23f1b9fcc88e5d4b65a8637b17723bbda3cce8",
+		HashType: Type,
+	}
+
+	s.SetArgs([]byte{0xc8, 0x32})
+
+	err := s.AppendArgs([]byte{0x8a, 0xab})
+	if err != nil {
+		t.Errorf("fail to append args: %s\n", err)
+		return
+	}
+
+	if s.Args != "0xc8328aab" {
+		t.Errorf("mismatch args, expect %v, got %v", "0xc8328aab", s.Args)
+		return
+	}
+
+	s.Args = "c832"
+
+	err = s.AppendArgs([]byte{0x8a})
+	if err == nil {
+		t.Errorf("expect error on invalid args")
+		return
+	}
+}
+
+func TestScriptHash(t *testing.T) {
+	s := Script{
+		CodeHash: "0x9bd7e06f3ecf4be0f2fcd2188b23f1b9fcc88e5d4b65a8637b17723bbda3cce8",
+		HashType: Type,
+		Args:     "0xc8328aabcd9b9e8e64fbc566c4385c3bdeb219d7",
+	}
+
+	expect := Hash("0x32e555f3ff8e135cece1351a6a2971518392c1e30375c1e006ad0ce8eac07947")
+
+	got, err := s.Hash()
+	if err != nil {
+		t.Errorf("fail to hash script: %s\n", err)
+		return
+	}
+
+	if got != expect {
+		t.Errorf("mismatch result, expect %v, got %v", expect, got)
+		return
+	}
+}
+
+func TestScriptHashEquals(t *testing.T) {
+	s := Script{
+		CodeHash: "0x9bd7e06f3ecf4be0f2fcd2188b23f1b9fcc88e5d4b65a8637b17723bbda3cce8",
+		HashType: Type,
+		Args:     "0xc8328aabcd9b9e8e64fbc566c4385c3bdeb219d7",
+	}
+
+	tests := []struct {
+		hash  Hash
+		match bool
+	}{
+		{"0x32e555f3ff8e135cece1351a6a2971518392c1e30375c1e006ad0ce8eac07947", true},
+		{"0x32E555F3FF8E135CECE1351A6A2971518392C1E30375C1E006AD0CE8EAC07947", true},
+		{"0xc219351b150b900e50a7039f1e448b844110927e5fd9bd30425806cb8ddff1fd", false},
+	}
+
+	for _, test := range tests {
+		got, err := s.HashEquals(test.hash)
+		if err != nil {
+			t.Errorf("fail to match script hash: %s\n", err)
+			return
+		}
+
+		if got != test.match {
+			t.Errorf("mismatch result %s, expect %v, got %v", test.hash, test.match, got)
+			return
+		}
+	}
+
+	_, err := s.HashEquals("0x32e555f3")
+	if err == nil {
+		t.Errorf("expect error on invalid lock hash")
+		return
+	}
+}
+
+func TestParseArgsFields(t *testing.T) {
+	args := Bytes("0x36c329ed630d6ce750712a477543672adab57f4c0000000000000000c0")
+
+	fields, err := ParseArgsFields(args, 20, 8)
+	if err != nil {
+		t.Errorf("fail to parse args fields: %s\n", err)
+		return
+	}
+
+	expect := []Bytes{
+		"0x36c329ed630d6ce750712a477543672adab57f4c",
+		"0x0000000000000000",
+		"0xc0",
+	}
+	if !reflect.DeepEqual(fields, expect) {
+		t.Errorf("mismatch result, expect %v, got %v", expect, fields)
+		return
+	}
+
+	fields, err = ParseArgsFields(args, 20, 9)
+	if err != nil {
+		t.Errorf("fail to parse args fields: %s\n", err)
+		return
+	}
+
+	if len(fields) != 2 {
+		t.Errorf("mismatch result, expect %v, got %v", 2, len(fields))
+		return
+	}
+
+	_, err = ParseArgsFields(args, 20, 10)
+	if err == nil {
+		t.Errorf("expect error on args too short")
+		return
+	}
+
+	_, err = ParseArgsFields(args, -1)
+	if err == nil {
+		t.Errorf("expect error on negative length")
+		return
+	}
+}
+
+func TestSortScriptsByHash(t *testing.T) {
+	alice := &Script{
+		CodeHash: "0x9bd7e06f3ecf4be0f2fcd2188b23f1b9fcc88e5d4b65a8637b17723bbda3cce8",
+		HashType: Type,
+		Args:     "0x470dcdc5e44064909650113a274b3b36aecb6dc7",
+	}
+	bob := &Script{
+		CodeHash: "0x9bd7e06f3ecf4be0f2fcd2188b23f1b9fcc88e5d4b65a8637b17723bbda3cce8",
+		HashType: Type,
+		Args:     "0xc8328aabcd9b9e8e64fbc566c4385c3bdeb219d7",
+	}
+	dao := &Script{
+		CodeHash: "0x82d76d1b75fe2fd9a27dfbaa65a039221a380d76c926f378d3f81cf3e7e13f2e",
+		HashType: Type,
+		Args:     "0x",
+	}
+	bobAgain := *bob
+
+	// hashes 0xc219..., 0xcc77..., 0x32e5...
+	scripts := []*Script{alice, dao, bob, &bobAgain}
+	err := SortScriptsByHash(scripts)
+	if err != nil {
+		t.Errorf("fail to sort scripts: %s\n", err)
+		return
+	}
+
+	expect := []*Script{bob, &bobAgain, alice, dao}
+	for i := range expect {
+		if scripts[i] != expect[i] {
+			t.Errorf("mismatch result at %d, expect %v, got %v", i, expect[i], scripts[i])
+			return
+		}
+	}
+
+	err = SortScriptsByHash([]*Script{alice, {CodeHash: "0x00", HashType: Type, Args: "0x"}})
+	if err == nil {
+		t.Errorf("expect error on invalid script")
+		return
+	}
+}
@@ -0,0 +1,95 @@
+package types
+
+import (
+	"fmt"
+)
+
+// Network ckb network
+type Network string
+
+// Network values
+const (
+	Mainnet Network = "mainnet"
+	Testnet Network = "testnet"
+)
+
+// Known script names
+const (
+	Secp256k1Blake160 = "secp256k1_blake160"
+	Multisig          = "multisig"
+	ACP               = "acp"
+	DAO               = "dao"
+	TypeIDScript      = "type_id"
+)
+
+// knownCodeHashes code hashes of known scripts, all of them use type hash type
+var knownCodeHashes = map[Network]map[string]Hash{
+	Mainnet: {
+		Secp256k1Blake160: "0x9bd7e06f3ecf4be0f2fcd2188b23f1b9fcc88e5d4b65a8637b17723bbda3cce8",
+		Multisig:          "0x5c5069eb0857efc65e1bca0c07df34c31663b3622fd3876c876320fc9634e2a8",
+		ACP:               "0xd369597ff47f29fbc0d47d2e3775370d1250b85140c670e4718af712983a2354",
+		DAO:               "0x82d76d1b75fe2fd9a27dfbaa65a039221a380d76c926f378d3f81cf3e7e13f2e",
+		TypeIDScript:      "0x00000000000000000000000000000000000000000000000000545950455f4944",
+	},
+	Testnet: {
+		Secp256k1Blake160: "0x9bd7e06f3ecf4be0f2fcd2188b23f1b9fcc88e5d4b65a8637b17723bbda3cce8",
+		Multisig:          "0x5c5069eb0857efc65e1bca0c07df34c31663b3622fd3876c876320fc9634e2a8",
+		ACP:               "0x3419a1c09eb2567f6552ee7a8ecffd64155cffe0f1796e6e61ec088d740c1356",
+		DAO:               "0x82d76d1b75fe2fd9a27dfbaa65a039221a380d76c926f378d3f81cf3e7e13f2e",
+		TypeIDScript:      "0x00000000000000000000000000000000000000000000000000545950455f4944",
+	},
+}
+
+// sighashDepGroups secp256k1 blake160 dep group outpoints of genesis block
+var sighashDepGroups = map[Network]OutPoint{
+	Mainnet: {TxHash: "0x71a7ba8fc96349fea0ed3a5c47992e3b4084b031a42264a018e0072e8172e46c", Index: "0x0"},
+	Testnet: {TxHash: "0xf8de3bb47d055cdf460d93a2a6e1b05f7432f9777c8c474abf4eec1d4aee5d37", Index: "0x0"},
+}
+
+// SighashDep secp256k1 blake160 dep group cell dep of network
+func SighashDep(network Network) (*CellDep, error) {
+	o, ok := sighashDepGroups[network]
+	if !ok {
+		return nil, fmt.Errorf("unknown network %s", network)
+	}
+
+	return &CellDep{OutPoint: o, DepType: DepGroup}, nil
+}
+
+// SighashLock secp256k1 blake160 sighash all lock of network with pubkey hash args
+func SighashLock(network Network, pubkeyHashArgs Bytes) (*Script, error) {
+	codeHash, ok := knownCodeHashes[network][Secp256k1Blake160]
+	if !ok {
+		return nil, fmt.Errorf("unknown network %s", network)
+	}
+
+	args, err := pubkeyHashArgs.Raw()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(args) != 20 {
+		return nil, fmt.Errorf("invalid sighash args, should be 20 bytes")
+	}
+
+	return &Script{
+		CodeHash: codeHash,
+		HashType: Type,
+		Args:     pubkeyHashArgs,
+	}, nil
+}
+
+// KnownScript name of known script matching code hash on network
+func (s *Script) KnownScript(network Network) (string, bool) {
+	if s.HashType != Type {
+		return "", false
+	}
+
+	for name, codeHash := range knownCodeHashes[network] {
+		if s.CodeHash.Equal(codeHash) {
+			return name, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,75 @@
+package types
+
+import (
+	"testing"
+)
+
+func TestSighashLock(t *testing.T) {
+	lock, err := SighashLock(Mainnet, "0xc8328aabcd9b9e8e64fbc566c4385c3bdeb219d7")
+	if err != nil {
+		t.Errorf("fail to build sighash lock: %s\n", err)
+		return
+	}
+
+	expect := Hash("0x32e555f3ff8e135cece1351a6a2971518392c1e30375c1e006ad0ce8eac07947")
+
+	got, err := lock.Hash()
+	if err != nil {
+		t.Errorf("fail to hash lock: %s\n", err)
+		return
+	}
+
+	if got != expect {
+		t.Errorf("mismatch result, expect %v, got %v", expect, got)
+		return
+	}
+
+	_, err = SighashLock(Testnet, "0xc8328aabcd9b9e8e64fbc566c4385c3bdeb219")
+	if err == nil {
+		t.Errorf("expect error on 19 bytes args")
+		return
+	}
+
+	_, err = SighashLock(Network("devnet"), "0xc8328aabcd9b9e8e64fbc566c4385c3bdeb219d7")
+	if err == nil {
+		t.Errorf("expect error on unknown network")
+		return
+	}
+}
+
+func TestKnownScript(t *testing.T) {
+	tests := []struct {
+		network  Network
+		codeHash Hash
+		expect   string
+	}{
+		{Mainnet, "0x9bd7e06f3ecf4be0f2fcd2188b23f1b9fcc88e5d4b65a8637b17723bbda3cce8", Secp256k1Blake160},
+		{Testnet, "0x5c5069eb0857efc65e1bca0c07df34c31663b3622fd3876c876320fc9634e2a8", Multisig},
+		{Mainnet, "0x82D76D1B75FE2FD9A27DFBAA65A039221A380D76C926F378D3F81CF3E7E13F2E", DAO},
+		{Testnet, "0x3419a1c09eb2567f6552ee7a8ecffd64155cffe0f1796e6e61ec088d740c1356", ACP},
+	}
+
+	for _, test := range tests {
+		s := Script{CodeHash: test.codeHash, HashType: Type, Args: "0x"}
+
+		got, ok := s.KnownScript(test.network)
+		if !ok || got != test.expect {
+			t.Errorf("mismatch result, expect %v, got %v", test.expect, got)
+			return
+		}
+	}
+
+	// Testnet acp isn't known on mainnet
+	s := Script{CodeHash: "0x3419a1c09eb2567f6552ee7a8ecffd64155cffe0f1796e6e61ec088d740c1356", HashType: Type, Args: "0x"}
+	if name, ok := s.KnownScript(Mainnet); ok {
+		t.Errorf("expect unknown script, got %v", name)
+		return
+	}
+
+	// Data hash type doesn't match type id code hash
+	s = Script{CodeHash: "0x9bd7e06f3ecf4be0f2fcd2188b23f1b9fcc88e5d4b65a8637b17723bbda3cce8", HashType: Data, Args: "0x"}
+	if name, ok := s.KnownScript(Mainnet); ok {
+		t.Errorf("expect unknown script, got %v", name)
+		return
+	}
+}
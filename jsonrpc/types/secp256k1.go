@@ -0,0 +1,136 @@
+package types
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// secp256k1 public key recovery from signatures, for verifying sighash locks
+/*
+ * Signatures, messages and public keys are all public data. The point
+ * math is variable time big.Int in affine coordinates, so it must not be
+ * extended to signing, key derivation or anything else touching secret
+ * keys.
+ */
+
+var (
+	secp256k1P, _  = new(big.Int).SetString("fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f", 16)
+	secp256k1N, _  = new(big.Int).SetString("fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141", 16)
+	secp256k1Gx, _ = new(big.Int).SetString("79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798", 16)
+	secp256k1Gy, _ = new(big.Int).SetString("483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8", 16)
+)
+
+// secp256k1Point affine point, nil x is the point at infinity
+type secp256k1Point struct {
+	x, y *big.Int
+}
+
+// secp256k1Add point addition, variable time
+func secp256k1Add(a, b secp256k1Point) secp256k1Point {
+	if a.x == nil {
+		return b
+	}
+	if b.x == nil {
+		return a
+	}
+
+	p := secp256k1P
+	l := new(big.Int)
+
+	if a.x.Cmp(b.x) == 0 {
+		sum := new(big.Int).Add(a.y, b.y)
+		if sum.Mod(sum, p).Sign() == 0 {
+			return secp256k1Point{}
+		}
+
+		// Tangent slope 3x^2 / 2y
+		dy := new(big.Int).Lsh(a.y, 1)
+		l.Mul(a.x, a.x).Mul(l, big.NewInt(3)).Mul(l, dy.ModInverse(dy, p))
+	} else {
+		dx := new(big.Int).Sub(b.x, a.x)
+		dx.Mod(dx, p)
+		l.Sub(b.y, a.y).Mul(l, dx.ModInverse(dx, p))
+	}
+	l.Mod(l, p)
+
+	x := new(big.Int).Mul(l, l)
+	x.Sub(x, a.x).Sub(x, b.x).Mod(x, p)
+
+	y := new(big.Int).Sub(a.x, x)
+	y.Mul(y, l).Sub(y, a.y).Mod(y, p)
+
+	return secp256k1Point{x: x, y: y}
+}
+
+// secp256k1Mul scalar multiplication by double and add, variable time, k must be public
+func secp256k1Mul(a secp256k1Point, k *big.Int) secp256k1Point {
+	r := secp256k1Point{}
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		r = secp256k1Add(r, r)
+		if k.Bit(i) == 1 {
+			r = secp256k1Add(r, a)
+		}
+	}
+
+	return r
+}
+
+// secp256k1Recover recover compressed public key from 65 bytes signature r | s | recid
+func secp256k1Recover(hash []byte, signature []byte) ([]byte, error) {
+	if len(signature) != secp256k1SignatureSize {
+		return nil, fmt.Errorf("invalid signature, should be %d bytes", secp256k1SignatureSize)
+	}
+
+	r := new(big.Int).SetBytes(signature[:32])
+	s := new(big.Int).SetBytes(signature[32:64])
+	recid := signature[64]
+
+	n := secp256k1N
+	if recid > 3 || r.Sign() == 0 || s.Sign() == 0 || r.Cmp(n) >= 0 || s.Cmp(n) >= 0 {
+		return nil, fmt.Errorf("invalid signature")
+	}
+
+	// R.x is r, or r + n when recid has the overflow bit
+	x := new(big.Int).Set(r)
+	if recid&2 != 0 {
+		x.Add(x, n)
+	}
+	if x.Cmp(secp256k1P) >= 0 {
+		return nil, fmt.Errorf("invalid signature")
+	}
+
+	// y^2 = x^3 + 7, p = 3 mod 4 so sqrt is a power of (p + 1) / 4
+	y2 := new(big.Int).Exp(x, big.NewInt(3), secp256k1P)
+	y2.Add(y2, big.NewInt(7)).Mod(y2, secp256k1P)
+
+	y := new(big.Int).Exp(y2, new(big.Int).Rsh(new(big.Int).Add(secp256k1P, big.NewInt(1)), 2), secp256k1P)
+	if new(big.Int).Exp(y, big.NewInt(2), secp256k1P).Cmp(y2) != 0 {
+		return nil, fmt.Errorf("invalid signature")
+	}
+	if y.Bit(0) != uint(recid&1) {
+		y.Sub(secp256k1P, y)
+	}
+
+	// Q = r^-1 (sR - zG)
+	z := new(big.Int).SetBytes(hash)
+	rInv := new(big.Int).ModInverse(r, n)
+
+	u1 := new(big.Int).Neg(z)
+	u1.Mul(u1, rInv).Mod(u1, n)
+	u2 := new(big.Int).Mul(s, rInv)
+	u2.Mod(u2, n)
+
+	q := secp256k1Add(
+		secp256k1Mul(secp256k1Point{x: secp256k1Gx, y: secp256k1Gy}, u1),
+		secp256k1Mul(secp256k1Point{x: x, y: y}, u2),
+	)
+	if q.x == nil {
+		return nil, fmt.Errorf("invalid signature")
+	}
+
+	pub := make([]byte, 33)
+	pub[0] = 0x02 | byte(q.y.Bit(0))
+	q.x.FillBytes(pub[1:])
+
+	return pub, nil
+}
@@ -3,16 +3,21 @@ package types
 import (
 	"bytes"
 	"encoding/binary"
+	"fmt"
+	"math"
 	"reflect"
 )
 
 const u32Size uint32 = 4
 
-// MolSerializer molecule serialize interface
-type MolSerializer interface {
+// Serializable molecule serialize interface
+type Serializable interface {
 	Serialize() ([]byte, error)
 }
 
+// MolSerializer molecule serialize interface, alias of Serializable
+type MolSerializer = Serializable
+
 // serializeUint32 serialize uint32
 func serializeUint32(n uint32) []byte {
 	b := make([]byte, 4)
@@ -36,15 +41,105 @@ func SerializeArray(items []MolSerializer) ([][]byte, error) {
 	return ret, nil
 }
 
+// StructBuilder molecule struct builder
+/*
+ * A struct is a fixed size type, unlike table it has neither full size
+ * header nor field offsets:
+ *
+ *     Serialize all fields in it in the order they are declared.
+ */
+type StructBuilder struct {
+	buf bytes.Buffer
+}
+
+// Field append field
+func (s *StructBuilder) Field(field []byte) *StructBuilder {
+	s.buf.Write(field)
+
+	return s
+}
+
+// Build return struct bytes
+func (s *StructBuilder) Build() []byte {
+	return s.buf.Bytes()
+}
+
+// SerializeAll serialize all items
+func SerializeAll(items ...Serializable) ([][]byte, error) {
+	return SerializeArray(items)
+}
+
+// checkMoleculeSize check header bytes plus items size fits molecule uint32 size
+func checkMoleculeSize(header int, items [][]byte) error {
+	size := uint64(header)
+	for i := 0; i < len(items); i++ {
+		size += uint64(len(items[i]))
+	}
+
+	if size > math.MaxUint32 {
+		return fmt.Errorf("molecule size %d exceeds uint32", size)
+	}
+
+	return nil
+}
+
+// serializeItems serialize each item, error prefixed with index of failing item
+/*
+ * Serialize methods have pointer receivers, so P is the pointer type of
+ * T, which lets callers pass value slices like []CellDep directly.
+ */
+func serializeItems[T any, P interface {
+	*T
+	Serializable
+}](items []T) ([][]byte, error) {
+	ret := make([][]byte, len(items))
+	for i := 0; i < len(items); i++ {
+		b, err := P(&items[i]).Serialize()
+		if err != nil {
+			return nil, indexError(i, err)
+		}
+
+		ret[i] = b
+	}
+
+	return ret, nil
+}
+
+// SerializeFixVecOf serialize items to fixvec vector
+func SerializeFixVecOf[T any, P interface {
+	*T
+	Serializable
+}](items []T) ([]byte, error) {
+	ret, err := serializeItems[T, P](items)
+	if err != nil {
+		return nil, err
+	}
+
+	return SerializeStructVec(ret)
+}
+
+// SerializeDynVecOf serialize items to dynvec vector
+func SerializeDynVecOf[T any, P interface {
+	*T
+	Serializable
+}](items []T) ([]byte, error) {
+	ret, err := serializeItems[T, P](items)
+	if err != nil {
+		return nil, err
+	}
+
+	return SerializeDynVec(ret)
+}
+
 // SerializeStruct serialize struct
 func SerializeStruct(fields [][]byte) []byte {
-	b := new(bytes.Buffer)
+	s := new(StructBuilder)
 
 	for i := 0; i < len(fields); i++ {
-		b.Write(fields[i])
+		s.Field(fields[i])
 	}
 
-	return b.Bytes()
+	return s.Build()
 }
 
 // SerializeFixVec serialize fixvec vector
@@ -53,11 +148,17 @@ func SerializeStruct(fields [][]byte) []byte {
  *
  *     Serialize the length as a 32 bit unsigned integer in little-endian.
  *     Serialize all items in it.
+ *
+ * Vectors exceeding the uint32 molecule size are an error.
  */
-func SerializeFixVec(items [][]byte) []byte {
+func SerializeFixVec(items [][]byte) ([]byte, error) {
+	if err := checkMoleculeSize(int(u32Size), items); err != nil {
+		return nil, err
+	}
+
 	// Empty fix vector bytes
 	if len(items) == 0 {
-		return []byte{00, 00, 00, 00}
+		return []byte{00, 00, 00, 00}, nil
 	}
 
 	l := serializeUint32(uint32(len(items)))
@@ -70,7 +171,22 @@ func SerializeFixVec(items [][]byte) []byte {
 		b.Write(items[i])
 	}
 
-	return b.Bytes()
+	return b.Bytes(), nil
+}
+
+// SerializeStructVec serialize fixvec of struct items, which must be of equal size
+/*
+ * Unlike SerializeFixVec, items of different sizes are an error rather
+ * than a vector that can't be decoded.
+ */
+func SerializeStructVec(items [][]byte) ([]byte, error) {
+	for i := 1; i < len(items); i++ {
+		if len(items[i]) != len(items[0]) {
+			return nil, indexError(i, fmt.Errorf("invalid struct vec item, %d bytes but %d bytes expected", len(items[i]), len(items[0])))
+		}
+	}
+
+	return SerializeFixVec(items)
 }
 
 // SerializeDynVec serialize dynvec
@@ -80,41 +196,78 @@ func SerializeFixVec(items [][]byte) []byte {
  *     Serialize the full size in bytes as a 32 bit unsigned integer in little-endian.
  *     Serialize all offset of items as 32 bit unsigned integer in little-endian.
  *     Serialize all items in it.
+ *
+ * Vectors exceeding the uint32 molecule size are an error.
  */
-func SerializeDynVec(items [][]byte) []byte {
-	// Start with u32Size
-	size := u32Size
+func SerializeDynVec(items [][]byte) ([]byte, error) {
+	if err := checkMoleculeSize(int(u32Size)*(len(items)+1), items); err != nil {
+		return nil, err
+	}
 
-	// Empty dyn vector, just return size's bytes
-	if len(items) == 0 {
-		return serializeUint32(size)
+	// First pass, calculate full size so the buffer is allocated once
+	size := u32Size + u32Size*uint32(len(items))
+	for i := 0; i < len(items); i++ {
+		size += uint32(len(items[i]))
 	}
 
-	offsets := make([]uint32, len(items))
+	b := make([]byte, size)
+	binary.LittleEndian.PutUint32(b, size)
 
-	// Calculate first offset then loop for rest items offsets
-	offsets[0] = size + u32Size*uint32(len(items))
+	// Second pass, write offsets and items in place
+	offset := u32Size + u32Size*uint32(len(items))
 	for i := 0; i < len(items); i++ {
-		size += u32Size + uint32(len(items[i]))
+		binary.LittleEndian.PutUint32(b[u32Size*uint32(i+1):], offset)
+		offset += uint32(copy(b[offset:], items[i]))
+	}
 
-		if i != 0 {
-			offsets[i] = offsets[i-1] + uint32(len(items[i-1]))
-		}
+	return b, nil
+}
+
+// serializeDynVecSized serialize items to dynvec without holding all item bytes
+/*
+ * Sizes are computed up front by rawLen, then each item is serialized
+ * straight into its place, so peak memory is the vector plus one item
+ * instead of twice the vector. Items are validated only by Serialize, a
+ * size mismatch from an invalid item is reported as an error.
+ */
+func serializeDynVecSized[T any, P interface {
+	*T
+	Serializable
+	rawLen() int
+}](items []T) ([]byte, error) {
+	total := uint64(u32Size) * uint64(len(items)+1)
+	for i := 0; i < len(items); i++ {
+		total += uint64(P(&items[i]).rawLen())
 	}
 
-	b := new(bytes.Buffer)
+	if total > math.MaxUint32 {
+		return nil, fmt.Errorf("molecule size %d exceeds uint32", total)
+	}
+	size := uint32(total)
 
-	b.Write(serializeUint32(size))
+	b := make([]byte, size)
+	binary.LittleEndian.PutUint32(b, size)
 
+	offset := u32Size + u32Size*uint32(len(items))
 	for i := 0; i < len(items); i++ {
-		b.Write(serializeUint32(offsets[i]))
+		item, err := P(&items[i]).Serialize()
+		if err != nil {
+			return nil, indexError(i, err)
+		}
+
+		if uint32(len(item)) > size-offset {
+			return nil, indexError(i, fmt.Errorf("serialized size exceeds computed size"))
+		}
+
+		binary.LittleEndian.PutUint32(b[u32Size*uint32(i+1):], offset)
+		offset += uint32(copy(b[offset:], item))
 	}
 
-	for i := 0; i < len(items); i++ {
-		b.Write(items[i])
+	if offset != size {
+		return nil, fmt.Errorf("serialized size %d mismatch computed size %d", offset, size)
 	}
 
-	return b.Bytes()
+	return b, nil
 }
 
 // SerializeTable serialize table
@@ -126,6 +279,11 @@ func SerializeDynVec(items [][]byte) []byte {
  *     Serialize all fields in it in the order they are declared.
  */
 func SerializeTable(fields [][]byte) []byte {
+	// Empty table, only the full size
+	if len(fields) == 0 {
+		return serializeUint32(u32Size)
+	}
+
 	size := u32Size
 	offsets := make([]uint32, len(fields))
 
@@ -162,3 +320,19 @@ func SerializeOption(o MolSerializer) ([]byte, error) {
 
 	return o.Serialize()
 }
+
+// SerializeUnion serialize union
+/*
+ * There are two steps of serializing a union:
+ *
+ *     Serialize the item id as a 32 bit unsigned integer in little-endian.
+ *     Serialize the inner item.
+ */
+func SerializeUnion(itemID uint32, inner []byte) []byte {
+	b := new(bytes.Buffer)
+
+	b.Write(serializeUint32(itemID))
+	b.Write(inner)
+
+	return b.Bytes()
+}
@@ -1,14 +1,36 @@
 package types
 
 import (
-	"bytes"
 	"encoding/binary"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
 )
 
+// All molecule types are Serializable
+var (
+	_ Serializable = (*Byte)(nil)
+	_ Serializable = (*Byte32)(nil)
+	_ Serializable = (*Hash)(nil)
+	_ Serializable = (*ScriptHashType)(nil)
+	_ Serializable = (*DepType)(nil)
+	_ Serializable = (*Bytes)(nil)
+	_ Serializable = (*Uint32)(nil)
+	_ Serializable = (*Uint64)(nil)
+	_ Serializable = (*Uint32Vec)(nil)
+	_ Serializable = (*Uint64Vec)(nil)
+	_ Serializable = (*ProposalShortID)(nil)
+	_ Serializable = (*Script)(nil)
+	_ Serializable = (*OutPoint)(nil)
+	_ Serializable = (*CellInput)(nil)
+	_ Serializable = (*CellOutput)(nil)
+	_ Serializable = (*CellDep)(nil)
+	_ Serializable = (*WitnessArgs)(nil)
+	_ Serializable = (*Transaction)(nil)
+)
+
 func check0xPrefix(s string) error {
 	if !strings.HasPrefix(s, "0x") {
 		return fmt.Errorf("invalid value, should be 0x-prefix")
@@ -16,6 +38,42 @@ func check0xPrefix(s string) error {
 	return nil
 }
 
+// buildTable serialize table of composite type, guarding size overflow
+func buildTable(fields [][]byte) ([]byte, error) {
+	if err := checkMoleculeSize(int(u32Size)*(len(fields)+1), fields); err != nil {
+		return nil, err
+	}
+
+	return checkedTable(SerializeTable(fields), len(fields))
+}
+
+// Serialize byte
+func (b *Byte) Serialize() ([]byte, error) {
+	inner := string(*b)
+
+	err := check0xPrefix(inner)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := hex.DecodeString(inner[2:])
+	if err != nil {
+		return nil, err
+	}
+
+	if len(r) != 1 {
+		return nil, fmt.Errorf("invalid byte, should be 1 byte")
+	}
+
+	return r, nil
+}
+
+// Serialize byte32
+func (b *Byte32) Serialize() ([]byte, error) {
+	h := Hash(*b)
+	return h.Serialize()
+}
+
 // Serialize hash
 func (h *Hash) Serialize() ([]byte, error) {
 	inner := string(*h)
@@ -39,17 +97,16 @@ func (h *Hash) Serialize() ([]byte, error) {
 
 // Serialize script hash type
 func (t *ScriptHashType) Serialize() ([]byte, error) {
-	inner := string(*t)
-
-	if strings.Compare(inner, string(Data)) != 0 && strings.Compare(inner, string(Type)) != 0 {
-		return nil, fmt.Errorf("invalid script hash type")
-	}
-
-	if strings.Compare(inner, string(Data)) == 0 {
+	switch *t {
+	case Data:
 		return []byte{00}, nil
+	case Type:
+		return []byte{01}, nil
+	case Data1:
+		return []byte{02}, nil
 	}
 
-	return []byte{01}, nil
+	return nil, fmt.Errorf("invalid script hash type")
 }
 
 // Serialize dep type
@@ -69,15 +126,12 @@ func (t *DepType) Serialize() ([]byte, error) {
 
 // Serialize bytes
 func (b *Bytes) Serialize() ([]byte, error) {
-	inner := string(*b)
-
-	err := check0xPrefix(inner)
+	decoded, err := b.Raw()
 	if err != nil {
 		return nil, err
 	}
 
-	decoded, err := hex.DecodeString(inner[2:])
-	if err != nil {
+	if err := checkMoleculeSize(int(u32Size), [][]byte{decoded}); err != nil {
 		return nil, err
 	}
 
@@ -86,228 +140,251 @@ func (b *Bytes) Serialize() ([]byte, error) {
 		bytes[i] = []byte{decoded[i]}
 	}
 
-	return SerializeFixVec(bytes), nil
+	return SerializeFixVec(bytes)
 }
 
 // Serialize uint32
 func (u *Uint32) Serialize() ([]byte, error) {
-	inner := string(*u)
-
-	err := check0xPrefix(inner)
+	n, err := u.Uint32()
 	if err != nil {
 		return nil, err
 	}
 
-	uu := inner[2:]
-	if len(inner)%2 != 0 {
-		uu = "0" + uu
-	}
+	return serializeUint32(n), nil
+}
 
-	n, err := strconv.ParseUint(uu, 16, 32)
+// Serialize uint64
+func (u *Uint64) Serialize() ([]byte, error) {
+	n, err := u.Uint64()
 	if err != nil {
 		return nil, err
 	}
 
-	return serializeUint32(uint32(n)), nil
+	b := make([]byte, 8)
+	binary.LittleEndian.PutUint64(b, n)
+
+	return b, nil
 }
 
-// Serialize uint64
-func (u *Uint64) Serialize() ([]byte, error) {
-	inner := string(*u)
+// Serialize uint32 vector
+func (v *Uint32Vec) Serialize() ([]byte, error) {
+	return SerializeFixVecOf(*v)
+}
+
+// Serialize uint64 vector
+func (v *Uint64Vec) Serialize() ([]byte, error) {
+	return SerializeFixVecOf(*v)
+}
+
+// Serialize outpoint vector
+func (v *OutPointVec) Serialize() ([]byte, error) {
+	return SerializeFixVecOf(*v)
+}
+
+// Serialize proposal short id
+func (p *ProposalShortID) Serialize() ([]byte, error) {
+	inner := string(*p)
 
 	err := check0xPrefix(inner)
 	if err != nil {
 		return nil, err
 	}
 
-	uu := inner[2:]
-	if len(inner)%2 != 0 {
-		uu = "0" + uu
-	}
-
-	n, err := strconv.ParseUint(uu, 16, 64)
+	b, err := hex.DecodeString(inner[2:])
 	if err != nil {
 		return nil, err
 	}
 
-	b := make([]byte, 8)
-	binary.LittleEndian.PutUint64(b, n)
+	if len(b) != proposalShortIDSize {
+		return nil, fmt.Errorf("invalid proposal short id, should be %d bytes", proposalShortIDSize)
+	}
 
 	return b, nil
 }
 
+// SerializeProposalShortIDVec serialize proposal short ids as fixvec
+func SerializeProposalShortIDVec(ids []ProposalShortID) ([]byte, error) {
+	return SerializeFixVecOf(ids)
+}
+
 // Serialize script
 func (s *Script) Serialize() ([]byte, error) {
 	h, err := s.CodeHash.Serialize()
 	if err != nil {
-		return nil, err
+		return nil, fieldError("code_hash", err)
 	}
 
 	t, err := s.HashType.Serialize()
 	if err != nil {
-		return nil, err
+		return nil, fieldError("hash_type", err)
 	}
 
 	a, err := s.Args.Serialize()
 	if err != nil {
-		return nil, err
+		return nil, fieldError("args", err)
 	}
 
-	return SerializeTable([][]byte{h, t, a}), nil
+	return buildTable([][]byte{h, t, a})
 }
 
 // Serialize outpoint
 func (o *OutPoint) Serialize() ([]byte, error) {
 	h, err := o.TxHash.Serialize()
 	if err != nil {
-		return nil, err
+		return nil, fieldError("tx_hash", err)
 	}
 
 	i, err := o.Index.Serialize()
 	if err != nil {
-		return nil, err
-	}
-
-	b := new(bytes.Buffer)
+		var numErr *strconv.NumError
+		if errors.As(err, &numErr) && numErr.Err == strconv.ErrRange {
+			return nil, fmt.Errorf("OutPoint.Index exceeds uint32 range: %s", o.Index)
+		}
 
-	b.Write(h)
-	b.Write(i)
+		return nil, fieldError("index", err)
+	}
 
-	return b.Bytes(), nil
+	return new(StructBuilder).Field(h).Field(i).Build(), nil
 }
 
 // Serialize cell input
 func (i *CellInput) Serialize() ([]byte, error) {
 	s, err := i.Since.Serialize()
 	if err != nil {
-		return nil, err
+		return nil, fieldError("since", err)
 	}
 
 	o, err := i.PreviousOutput.Serialize()
 	if err != nil {
-		return nil, err
+		return nil, fieldError("previous_output", err)
 	}
 
-	return SerializeStruct([][]byte{s, o}), nil
+	return new(StructBuilder).Field(s).Field(o).Build(), nil
 }
 
 // Serialize cell output
 func (o *CellOutput) Serialize() ([]byte, error) {
 	c, err := o.Capacity.Serialize()
 	if err != nil {
-		return nil, err
+		return nil, fieldError("capacity", err)
 	}
 
 	l, err := o.Lock.Serialize()
 	if err != nil {
-		return nil, err
+		return nil, fieldError("lock", err)
 	}
 
 	t, err := SerializeOption(o.Type)
 	if err != nil {
-		return nil, err
+		return nil, fieldError("type", err)
 	}
 
-	return SerializeTable([][]byte{c, l, t}), nil
+	return buildTable([][]byte{c, l, t})
 }
 
 // Serialize cell dep
 func (d *CellDep) Serialize() ([]byte, error) {
 	o, err := d.OutPoint.Serialize()
 	if err != nil {
-		return nil, err
+		return nil, fieldError("out_point", err)
 	}
 
 	dd, err := d.DepType.Serialize()
 	if err != nil {
-		return nil, err
+		return nil, fieldError("dep_type", err)
 	}
 
-	return SerializeStruct([][]byte{o, dd}), nil
+	return new(StructBuilder).Field(o).Field(dd).Build(), nil
 }
 
 // Serialize witness args
 func (w *WitnessArgs) Serialize() ([]byte, error) {
 	l, err := SerializeOption(w.Lock)
 	if err != nil {
-		return nil, err
+		return nil, fieldError("lock", err)
 	}
 
 	i, err := SerializeOption(w.InputType)
 	if err != nil {
-		return nil, err
+		return nil, fieldError("input_type", err)
 	}
 
 	o, err := SerializeOption(w.OutputType)
 	if err != nil {
-		return nil, err
+		return nil, fieldError("output_type", err)
 	}
 
-	return SerializeTable([][]byte{l, i, o}), nil
+	return buildTable([][]byte{l, i, o})
 }
 
 // Serialize transaction
 func (t *Transaction) Serialize() ([]byte, error) {
-	v, err := t.Version.Serialize()
+	v, cds, hds, ips, ops, ods, err := t.RawFields()
 	if err != nil {
 		return nil, err
 	}
 
-	// Ok, no way around this
-	deps := make([]MolSerializer, len(t.CellDeps))
-	for i, v := range t.CellDeps {
-		deps[i] = &v
+	fields := [][]byte{v, cds, hds, ips, ops, ods}
+	return buildTable(fields)
+}
+
+// RawFields serialized fields of raw transaction, in table order
+func (t *Transaction) RawFields() (version, cellDeps, headerDeps, inputs, outputs, outputsData []byte, err error) {
+	version, err = t.Version.Serialize()
+	if err != nil {
+		return nil, nil, nil, nil, nil, nil, fieldError("version", err)
 	}
-	cds, err := SerializeArray(deps)
+
+	cellDeps, err = SerializeFixVecOf(t.CellDeps)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, nil, nil, nil, fieldError("cell_deps", err)
 	}
-	cdsBytes := SerializeFixVec(cds)
 
-	hds := make([][]byte, len(t.HeaderDeps))
-	for i := 0; i < len(t.HeaderDeps); i++ {
-		hd, err := t.HeaderDeps[i].Serialize()
-		if err != nil {
-			return nil, err
-		}
+	headerDeps, err = SerializeFixVecOf(t.HeaderDeps)
+	if err != nil {
+		return nil, nil, nil, nil, nil, nil, fieldError("header_deps", err)
+	}
 
-		hds[i] = hd
+	inputs, err = SerializeFixVecOf(t.Inputs)
+	if err != nil {
+		return nil, nil, nil, nil, nil, nil, fieldError("inputs", err)
 	}
-	hdsBytes := SerializeFixVec(hds)
 
-	ips := make([][]byte, len(t.Inputs))
-	for i := 0; i < len(t.Inputs); i++ {
-		ip, err := t.Inputs[i].Serialize()
-		if err != nil {
-			return nil, err
-		}
+	outputs, err = serializeDynVecSized(t.Outputs)
+	if err != nil {
+		return nil, nil, nil, nil, nil, nil, fieldError("outputs", err)
+	}
 
-		ips[i] = ip
+	outputsData, err = SerializeDynVecOf(t.OutputsData)
+	if err != nil {
+		return nil, nil, nil, nil, nil, nil, fieldError("outputs_data", err)
 	}
-	ipsBytes := SerializeFixVec(ips)
 
-	ops := make([][]byte, len(t.Outputs))
-	for i := 0; i < len(t.Outputs); i++ {
-		op, err := t.Outputs[i].Serialize()
-		if err != nil {
-			return nil, err
-		}
+	return version, cellDeps, headerDeps, inputs, outputs, outputsData, nil
+}
 
-		ops[i] = op
-	}
-	opsBytes := SerializeDynVec(ops)
+// FullSerialize serialize transaction with witnesses
+/*
+ * The full transaction is a table of two fields:
+ *
+ *     raw:       raw transaction, same as Serialize
+ *     witnesses: dynvec of bytes
+ */
+func (t *Transaction) FullSerialize() ([]byte, error) {
+	return t.fullSerialize(t.Witnesses)
+}
 
-	ods := make([][]byte, len(t.OutputsData))
-	for i := 0; i < len(t.OutputsData); i++ {
-		od, err := t.OutputsData[i].Serialize()
-		if err != nil {
-			return nil, err
-		}
+// fullSerialize serialize transaction with given witnesses
+func (t *Transaction) fullSerialize(witnesses []Bytes) ([]byte, error) {
+	raw, err := t.Serialize()
+	if err != nil {
+		return nil, err
+	}
 
-		ods[i] = od
+	ws, err := SerializeDynVecOf(witnesses)
+	if err != nil {
+		return nil, fieldError("witnesses", err)
 	}
-	odsBytes := SerializeDynVec(ods)
 
-	fields := [][]byte{v, cdsBytes, hdsBytes, ipsBytes, opsBytes, odsBytes}
-	return SerializeTable(fields), nil
+	return buildTable([][]byte{raw, ws})
 }
@@ -1,8 +1,12 @@
 package types
 
 import (
+	"bytes"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -256,3 +260,587 @@ func TestSerializeTransaction(t *testing.T) {
 		return
 	}
 }
+
+func TestSerializeOutPointIndexOutOfRange(t *testing.T) {
+	o := OutPoint{
+		TxHash: "0xe49352ee4984694d88eb3c1493a33d69d61c786dc5b0a32c4b3978d4fad64379",
+		Index:  "0x100000000",
+	}
+
+	expect := "OutPoint.Index exceeds uint32 range: 0x100000000"
+
+	_, err := o.Serialize()
+	if err == nil || err.Error() != expect {
+		t.Errorf("mismatch error, expect %v, got %v", expect, err)
+		return
+	}
+}
+
+func TestNewOutPoint(t *testing.T) {
+	o, err := NewOutPoint("0xe49352ee4984694d88eb3c1493a33d69d61c786dc5b0a32c4b3978d4fad64379", 6)
+	if err != nil {
+		t.Errorf("fail to create outpoint: %s\n", err)
+		return
+	}
+
+	if o.Index != "0x6" {
+		t.Errorf("mismatch index, expect %v, got %v", "0x6", o.Index)
+		return
+	}
+
+	_, err = NewOutPoint("0xe49352ee4984694d88eb3c1493a33d69d61c786dc5b0a32c4b3978d4fad643", 6)
+	if err == nil {
+		t.Errorf("expect error on short tx hash")
+		return
+	}
+}
+
+func TestSerializeStructNoHeader(t *testing.T) {
+	txHash := Hash("0xe49352ee4984694d88eb3c1493a33d69d61c786dc5b0a32c4b3978d4fad64379")
+	outpoint := OutPoint{TxHash: txHash, Index: "0x6"}
+	input := CellInput{Since: "0x0", PreviousOutput: outpoint}
+	dep := CellDep{OutPoint: outpoint, DepType: DepGroup}
+
+	h, _ := txHash.Serialize()
+
+	// Struct only concatenates fields, so its length is exactly the sum of
+	// field sizes, and the first field starts at byte 0.
+	structs := []struct {
+		name  string
+		s     MolSerializer
+		size  int
+		first []byte
+	}{
+		{"outpoint", &outpoint, 36, h},
+		{"cell input", &input, 44, make([]byte, 8)},
+		{"cell dep", &dep, 37, h},
+	}
+
+	for _, s := range structs {
+		got, err := s.s.Serialize()
+		if err != nil {
+			t.Errorf("fail to serialize %s: %s\n", s.name, err)
+			return
+		}
+
+		if len(got) != s.size {
+			t.Errorf("mismatch %s size, expect %v, got %v", s.name, s.size, len(got))
+			return
+		}
+
+		if !bytes.Equal(got[:len(s.first)], s.first) {
+			t.Errorf("unexpected header in %s: %x", s.name, got)
+			return
+		}
+	}
+}
+
+func TestSerializeEmptyBytes(t *testing.T) {
+	b := EmptyBytes()
+
+	got, err := b.Serialize()
+	if err != nil {
+		t.Errorf("fail to serialize: %s\n", err)
+		return
+	}
+
+	gotHex := hex.EncodeToString(got)
+	if gotHex != "00000000" {
+		t.Errorf("mismatch result, expect %v, got %v", "00000000", gotHex)
+		return
+	}
+
+	b = Bytes("")
+
+	_, err = b.Serialize()
+	if err == nil || !strings.Contains(err.Error(), `use "0x" for empty bytes`) {
+		t.Errorf("expect empty bytes hint, got %v", err)
+		return
+	}
+}
+
+func TestSerializeTransactionWithoutOutputs(t *testing.T) {
+	tx := Transaction{
+		Version: "0x0",
+		CellDeps: []CellDep{
+			{
+				OutPoint: OutPoint{
+					TxHash: "0xb815a396c5226009670e89ee514850dcde452bca746cdd6b41c104b50e559c70",
+					Index:  "0x0",
+				},
+				DepType: DepGroup,
+			},
+		},
+		HeaderDeps: []Hash{},
+		Inputs: []CellInput{
+			{
+				PreviousOutput: OutPoint{
+					TxHash: "0xee046ce2baeda575266d4164f394c53f66009f64759f7a9f12a014c692e79390",
+					Index:  "0x6",
+				},
+				Since: "0x0",
+			},
+		},
+		Outputs:     []CellOutput{},
+		Witnesses:   []Bytes{},
+		OutputsData: []Bytes{},
+	}
+
+	// Empty outputs and outputs data are both dynvec with only full size
+	expectHex := "850000001c00000020000000490000004d0000007d000000810000000000000001000000b815a396c5226009670e89ee514850dcde452bca746cdd6b41c104b50e559c70000000000100000000010000000000000000000000ee046ce2baeda575266d4164f394c53f66009f64759f7a9f12a014c692e79390060000000400000004000000"
+
+	got, err := tx.Serialize()
+	if err != nil {
+		t.Errorf("fail to serialize: %s\n", err)
+		return
+	}
+
+	gotHex := hex.EncodeToString(got)
+
+	if gotHex != expectHex {
+		t.Errorf("mismatch result, expect %v, got %v", expectHex, gotHex)
+		return
+	}
+
+	empty, err := SerializeDynVec([][]byte{})
+	if err != nil || hex.EncodeToString(empty) != "04000000" {
+		t.Errorf("mismatch empty dynvec, got %x (%v)", empty, err)
+		return
+	}
+}
+
+func TestSerializeUnion(t *testing.T) {
+	script := Script{
+		CodeHash: "0x9bd7e06f3ecf4be0f2fcd2188b23f1b9fcc88e5d4b65a8637b17723bbda3cce8",
+		HashType: Type,
+		Args:     "0xc8328aabcd9b9e8e64fbc566c4385c3bdeb219d7",
+	}
+	s, _ := script.Serialize()
+
+	args := Bytes("0x0102")
+	a, _ := args.Serialize()
+
+	variants := []struct {
+		id        uint32
+		inner     []byte
+		expectHex string
+	}{
+		{0, s, "00000000" + hex.EncodeToString(s)},
+		{1, a, "01000000020000000102"},
+	}
+
+	for _, v := range variants {
+		got := SerializeUnion(v.id, v.inner)
+
+		gotHex := hex.EncodeToString(got)
+		if gotHex != v.expectHex {
+			t.Errorf("mismatch result, expect %v, got %v", v.expectHex, gotHex)
+			return
+		}
+
+		id, inner, err := DeserializeUnion(got)
+		if err != nil {
+			t.Errorf("fail to deserialize union: %s\n", err)
+			return
+		}
+
+		if id != v.id || !bytes.Equal(inner, v.inner) {
+			t.Errorf("mismatch union, expect %v %x, got %v %x", v.id, v.inner, id, inner)
+			return
+		}
+	}
+
+	_, _, err := DeserializeUnion([]byte{0x01})
+	if err == nil {
+		t.Errorf("expect error on truncated union")
+		return
+	}
+}
+
+func TestSerializeAll(t *testing.T) {
+	v := Uint32("0x1")
+	h := Hash("0xb815a396c5226009670e89ee514850dcde452bca746cdd6b41c104b50e559c70")
+	d := Code
+
+	got, err := SerializeAll(&v, &h, &d)
+	if err != nil {
+		t.Errorf("fail to serialize: %s\n", err)
+		return
+	}
+
+	if len(got) != 3 || len(got[0]) != 4 || len(got[1]) != 32 || len(got[2]) != 1 {
+		t.Errorf("mismatch result, got %x", got)
+		return
+	}
+
+	b := Bytes("")
+
+	_, err = SerializeAll(&v, &b, &h)
+	if err == nil {
+		t.Errorf("expect error on invalid item")
+		return
+	}
+}
+
+func TestSerializeFieldError(t *testing.T) {
+	lock := Script{
+		CodeHash: "0x9bd7e06f3ecf4be0f2fcd2188b23f1b9fcc88e5d4b65a8637b17723bbda3cce8",
+		HashType: Type,
+		Args:     "0xc8328aabcd9b9e8e64fbc566c4385c3bdeb219d7",
+	}
+
+	tx := Transaction{
+		Version:     "0x0",
+		Outputs:     []CellOutput{{Capacity: "0x0", Lock: lock}, {Capacity: "0x0", Lock: lock}, {Capacity: "0x0", Lock: lock}},
+		OutputsData: []Bytes{"0x", "0x", "0x"},
+	}
+
+	// 31 bytes code hash
+	tx.Outputs[2].Lock.CodeHash = "0x9bd7e06f3ecf4be0f2fcd2188b23f1b9fcc88e5d4b65a8637b17723bbda3cc"
+
+	expect := "outputs[2].lock.code_hash: invalid hash, should be 32 bytes"
+
+	_, err := tx.Serialize()
+	if err == nil || err.Error() != expect {
+		t.Errorf("mismatch error, expect %v, got %v", expect, err)
+		return
+	}
+
+	if errors.Unwrap(err) == nil || errors.Unwrap(err).Error() != "invalid hash, should be 32 bytes" {
+		t.Errorf("mismatch unwrapped error, got %v", errors.Unwrap(err))
+		return
+	}
+}
+
+func TestSerializeVecOf(t *testing.T) {
+	hashes := []Hash{
+		"0xb815a396c5226009670e89ee514850dcde452bca746cdd6b41c104b50e559c70",
+		"0xee046ce2baeda575266d4164f394c53f66009f64759f7a9f12a014c692e79390",
+	}
+
+	fv, err := SerializeFixVecOf(hashes)
+	if err != nil {
+		t.Errorf("fail to serialize fixvec: %s\n", err)
+		return
+	}
+
+	expect := "02000000" + strings.TrimPrefix(string(hashes[0]), "0x") + strings.TrimPrefix(string(hashes[1]), "0x")
+	if hex.EncodeToString(fv) != expect {
+		t.Errorf("mismatch result, expect %v, got %v", expect, hex.EncodeToString(fv))
+		return
+	}
+
+	data := []Bytes{"0x", "0x1234"}
+
+	dv, err := SerializeDynVecOf(data)
+	if err != nil {
+		t.Errorf("fail to serialize dynvec: %s\n", err)
+		return
+	}
+
+	expect = "16000000" + "0c000000" + "10000000" + "00000000" + "020000001234"
+	if hex.EncodeToString(dv) != expect {
+		t.Errorf("mismatch result, expect %v, got %v", expect, hex.EncodeToString(dv))
+		return
+	}
+
+	// Error reports index of failing item
+	data = append(data, Bytes("0x123"))
+
+	_, err = SerializeDynVecOf(data)
+	if err == nil || !strings.HasPrefix(err.Error(), "[2]: ") {
+		t.Errorf("mismatch error, expect index [2], got %v", err)
+		return
+	}
+}
+
+func BenchmarkSerializeTransactionOutputs(b *testing.B) {
+	lock := Script{
+		CodeHash: "0x9bd7e06f3ecf4be0f2fcd2188b23f1b9fcc88e5d4b65a8637b17723bbda3cce8",
+		HashType: Type,
+		Args:     "0xc8328aabcd9b9e8e64fbc566c4385c3bdeb219d7",
+	}
+
+	tx := Transaction{Version: "0x0"}
+	for i := 0; i < 5000; i++ {
+		tx.AddOutput(&CellOutput{Capacity: "0x1c6bf52634000", Lock: lock}, EmptyBytes())
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := tx.Serialize(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestSerializeByte(t *testing.T) {
+	b := Byte("0x09")
+
+	got, err := b.Serialize()
+	if err != nil {
+		t.Errorf("fail to serialize byte: %s\n", err)
+		return
+	}
+
+	if !bytes.Equal(got, []byte{0x09}) {
+		t.Errorf("mismatch result, expect %v, got %v", []byte{0x09}, got)
+		return
+	}
+
+	for _, invalid := range []Byte{"0x", "0x9", "0x0902", "09"} {
+		_, err := invalid.Serialize()
+		if err == nil {
+			t.Errorf("expect error on invalid byte %s", invalid)
+			return
+		}
+	}
+}
+
+func TestTransactionRawFields(t *testing.T) {
+	tx := Transaction{
+		Version:     "0x0",
+		HeaderDeps:  []Hash{"0xb815a396c5226009670e89ee514850dcde452bca746cdd6b41c104b50e559c70"},
+		OutputsData: []Bytes{"0x1234"},
+	}
+
+	v, cds, hds, ips, ops, ods, err := tx.RawFields()
+	if err != nil {
+		t.Errorf("fail to get raw fields: %s\n", err)
+		return
+	}
+
+	expect := []string{
+		"00000000",
+		"00000000",
+		"01000000b815a396c5226009670e89ee514850dcde452bca746cdd6b41c104b50e559c70",
+		"00000000",
+		"04000000",
+		"0e00000008000000020000001234",
+	}
+
+	got := []string{
+		hex.EncodeToString(v), hex.EncodeToString(cds), hex.EncodeToString(hds),
+		hex.EncodeToString(ips), hex.EncodeToString(ops), hex.EncodeToString(ods),
+	}
+
+	for i := range expect {
+		if got[i] != expect[i] {
+			t.Errorf("mismatch field %d, expect %v, got %v", i, expect[i], got[i])
+			return
+		}
+	}
+
+	b, _ := tx.Serialize()
+	if !bytes.Equal(b, SerializeTable([][]byte{v, cds, hds, ips, ops, ods})) {
+		t.Errorf("mismatch serialized transaction")
+		return
+	}
+}
+
+func TestSerializeSizeOverflow(t *testing.T) {
+	// Fields share one buffer, 1M fields of 4KB exceed uint32 without
+	// allocating 4GB
+	chunk := make([]byte, 4096)
+	fields := make([][]byte, 1<<20)
+	for i := range fields {
+		fields[i] = chunk
+	}
+
+	_, err := buildTable(fields)
+	if err == nil {
+		t.Errorf("expect error on table size overflow")
+		return
+	}
+
+	_, err = SerializeFixVec(fields)
+	if err == nil {
+		t.Errorf("expect error on fixvec size overflow")
+		return
+	}
+
+	_, err = SerializeDynVec(fields)
+	if err == nil {
+		t.Errorf("expect error on dynvec size overflow")
+		return
+	}
+
+	err = checkMoleculeSize(int(u32Size), [][]byte{chunk})
+	if err != nil {
+		t.Errorf("fail to check size: %s\n", err)
+		return
+	}
+}
+
+func TestSerializeScriptEmptyArgs(t *testing.T) {
+	// mainnet dao type script, args is a single empty bytes, 4 bytes length
+	// prefix only, not framed again by the table
+	s := Script{
+		CodeHash: "0x82d76d1b75fe2fd9a27dfbaa65a039221a380d76c926f378d3f81cf3e7e13f2e",
+		HashType: Type,
+		Args:     EmptyBytes(),
+	}
+
+	expectHex := "3500000010000000300000003100000082d76d1b75fe2fd9a27dfbaa65a039221a380d76c926f378d3f81cf3e7e13f2e0100000000"
+
+	got, err := s.Serialize()
+	if err != nil {
+		t.Errorf("fail to serialize: %s\n", err)
+		return
+	}
+
+	if hex.EncodeToString(got) != expectHex {
+		t.Errorf("mismatch result, expect %v, got %v", expectHex, hex.EncodeToString(got))
+		return
+	}
+
+	h, err := s.Hash()
+	if err != nil {
+		t.Errorf("fail to hash script: %s\n", err)
+		return
+	}
+
+	if h != "0xcc77c4deac05d68ab5b26828f0bf4565a8d73113d7bb7e92b8362b8a74e58e58" {
+		t.Errorf("mismatch result, expect %v, got %v", "0xcc77c4deac05d68ab5b26828f0bf4565a8d73113d7bb7e92b8362b8a74e58e58", h)
+		return
+	}
+}
+
+func TestSerializeStructVec(t *testing.T) {
+	got, err := SerializeStructVec([][]byte{{0x01, 0x02}, {0x03, 0x04}})
+	if err != nil {
+		t.Errorf("fail to serialize struct vec: %s\n", err)
+		return
+	}
+
+	if hex.EncodeToString(got) != "0200000001020304" {
+		t.Errorf("mismatch result, expect %v, got %v", "0200000001020304", hex.EncodeToString(got))
+		return
+	}
+
+	got, err = SerializeStructVec(nil)
+	if err != nil || hex.EncodeToString(got) != "00000000" {
+		t.Errorf("mismatch result, expect %v, got %x (%v)", "00000000", got, err)
+		return
+	}
+
+	_, err = SerializeStructVec([][]byte{{0x01, 0x02}, {0x03, 0x04}, {0x05}})
+	if err == nil || !strings.HasPrefix(err.Error(), "[2]: ") {
+		t.Errorf("expect error on mismatched item size, got %v", err)
+		return
+	}
+
+	_, err = SerializeStructVec([][]byte{{0x01}, {}})
+	if err == nil {
+		t.Errorf("expect error on mismatched item size")
+		return
+	}
+}
+
+func TestSerializeTransactionVersion(t *testing.T) {
+	tx := NewTransaction()
+
+	got, err := tx.Serialize()
+	if err != nil {
+		t.Errorf("fail to serialize: %s\n", err)
+		return
+	}
+
+	// version is the first field, right after the 28 bytes header
+	expectHex := "340000001c0000002000000024000000280000002c00000030000000000000000000000000000000000000000400000004000000"
+	if hex.EncodeToString(got) != expectHex {
+		t.Errorf("mismatch result, expect %v, got %v", expectHex, hex.EncodeToString(got))
+		return
+	}
+
+	tx.Version = "0x1020304"
+	got, err = tx.Serialize()
+	if err != nil {
+		t.Errorf("fail to serialize: %s\n", err)
+		return
+	}
+
+	if hex.EncodeToString(got[28:32]) != "04030201" {
+		t.Errorf("mismatch result, expect %v, got %v", "04030201", hex.EncodeToString(got[28:32]))
+		return
+	}
+
+	d, err := DeserializeTransaction(got)
+	if err != nil {
+		t.Errorf("fail to deserialize: %s\n", err)
+		return
+	}
+
+	if d.Version != tx.Version {
+		t.Errorf("mismatch result, expect %v, got %v", tx.Version, d.Version)
+		return
+	}
+}
+
+func TestSerializeWitnessArgsGolden(t *testing.T) {
+	lock := Bytes("0x" + hex.EncodeToString(make([]byte, 65)))
+	inputType := Bytes("0x1234")
+	outputType := Bytes("0xabababab")
+
+	tests := []struct {
+		witness   WitnessArgs
+		expectHex string
+	}{
+		// absent options are empty fields, offsets of lock and input type
+		// both point at the output type field
+		{WitnessArgs{OutputType: &outputType}, "1800000010000000100000001000000004000000abababab"},
+		{WitnessArgs{Lock: &lock, InputType: &inputType, OutputType: &outputType}, "6300000010000000550000005b00000041000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000002000000123404000000abababab"},
+		{WitnessArgs{}, "10000000100000001000000010000000"},
+	}
+
+	for _, test := range tests {
+		got, err := test.witness.Serialize()
+		if err != nil {
+			t.Errorf("fail to serialize: %s\n", err)
+			return
+		}
+
+		if hex.EncodeToString(got) != test.expectHex {
+			t.Errorf("mismatch result, expect %v, got %v", test.expectHex, hex.EncodeToString(got))
+			return
+		}
+
+		w, err := DeserializeWitnessArgs(got)
+		if err != nil {
+			t.Errorf("fail to deserialize: %s\n", err)
+			return
+		}
+
+		if !reflect.DeepEqual(&test.witness, w) {
+			t.Errorf("mismatch result, expect %v, got %v", test.witness, *w)
+			return
+		}
+	}
+}
+
+func TestSerializeEmptyTable(t *testing.T) {
+	got := SerializeTable(nil)
+	if hex.EncodeToString(got) != "04000000" {
+		t.Errorf("mismatch result, expect %v, got %v", "04000000", hex.EncodeToString(got))
+		return
+	}
+
+	fields, err := DeserializeTable(got)
+	if err != nil {
+		t.Errorf("fail to deserialize table: %s\n", err)
+		return
+	}
+
+	if len(fields) != 0 {
+		t.Errorf("mismatch result, expect %v, got %v", 0, len(fields))
+		return
+	}
+
+	_, _, err = decodeTableAt(got, 0, 0)
+	if err != nil {
+		t.Errorf("fail to decode table: %s\n", err)
+		return
+	}
+}
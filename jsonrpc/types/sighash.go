@@ -0,0 +1,176 @@
+package types
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"sort"
+)
+
+// writeWitness hash witness length as u64 little-endian then witness bytes
+func writeWitness(h hash.Hash, witness []byte) {
+	l := make([]byte, 8)
+	binary.LittleEndian.PutUint64(l, uint64(len(witness)))
+
+	h.Write(l)
+	h.Write(witness)
+}
+
+// sighashMessage signing message of lock group
+/*
+ * The message is blake2b-256 of:
+ *
+ *     tx hash
+ *     first witness of group, with its lock zero filled
+ *     rest witnesses of group
+ *     witnesses beyond inputs
+ *
+ * Witness is prefixed with its length as u64 little-endian. Returns the
+ * message and the lock of the first witness.
+ */
+func (t *Transaction) sighashMessage(group []int, witnesses []Bytes) ([]byte, []byte, error) {
+	txHash, err := t.Hash()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rawHash, err := txHash.Serialize()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, i := range group {
+		if i >= len(witnesses) {
+			return nil, nil, fmt.Errorf("missing witnesses[%d]", i)
+		}
+	}
+
+	first, err := witnesses[group[0]].Raw()
+	if err != nil {
+		return nil, nil, fieldError(fmt.Sprintf("witnesses[%d]", group[0]), err)
+	}
+
+	w, err := DeserializeWitnessArgs(first)
+	if err != nil {
+		return nil, nil, fieldError(fmt.Sprintf("witnesses[%d]", group[0]), err)
+	}
+
+	if w.Lock == nil {
+		return nil, nil, fmt.Errorf("witnesses[%d] has no lock", group[0])
+	}
+
+	lock, err := w.Lock.Raw()
+	if err != nil {
+		return nil, nil, fieldError(fmt.Sprintf("witnesses[%d].lock", group[0]), err)
+	}
+
+	zeroed := BytesFromRaw(make([]byte, len(lock)))
+	w.Lock = &zeroed
+
+	placeholder, err := w.Serialize()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	h := NewCKBHasher()
+	h.Write(rawHash)
+	writeWitness(h, placeholder)
+
+	rest := append([]int{}, group[1:]...)
+	for i := len(t.Inputs); i < len(witnesses); i++ {
+		rest = append(rest, i)
+	}
+
+	for _, i := range rest {
+		b, err := witnesses[i].Raw()
+		if err != nil {
+			return nil, nil, fieldError(fmt.Sprintf("witnesses[%d]", i), err)
+		}
+
+		writeWitness(h, b)
+	}
+
+	return h.Sum(nil), lock, nil
+}
+
+// GroupInputsByLock input indexes grouped by lock script hash
+/*
+ * InputLocks are the resolved locks of inputs in order, indexes in each
+ * group are ascending.
+ */
+func GroupInputsByLock(t *Transaction, inputLocks []*Script) (map[Hash][]int, error) {
+	if len(inputLocks) != len(t.Inputs) {
+		return nil, fmt.Errorf("mismatch input locks, expect %d, got %d", len(t.Inputs), len(inputLocks))
+	}
+
+	groups := make(map[Hash][]int)
+	for i := 0; i < len(inputLocks); i++ {
+		h, err := inputLocks[i].Hash()
+		if err != nil {
+			return nil, fieldError(fmt.Sprintf("input_locks[%d]", i), err)
+		}
+
+		groups[h] = append(groups[h], i)
+	}
+
+	return groups, nil
+}
+
+// sortedGroups groups ordered by their first input
+func sortedGroups(groups map[Hash][]int) [][]int {
+	ret := make([][]int, 0, len(groups))
+	for _, g := range groups {
+		ret = append(ret, g)
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i][0] < ret[j][0]
+	})
+
+	return ret
+}
+
+// VerifySighash verify secp256k1 blake160 sighash all signatures
+/*
+ * Inputs are grouped by lock script, inputLocks are the resolved locks
+ * of inputs in order. Witnesses are used in place of t.Witnesses. Returns
+ * false if any group signature doesn't match the pubkey hash in lock
+ * args. Non sighash locks of network and malformed signatures are an
+ * error.
+ */
+func (t *Transaction) VerifySighash(network Network, inputLocks []*Script, witnesses []Bytes) (bool, error) {
+	groups, err := GroupInputsByLock(t, inputLocks)
+	if err != nil {
+		return false, err
+	}
+
+	for _, group := range sortedGroups(groups) {
+		lock := inputLocks[group[0]]
+
+		if name, ok := lock.KnownScript(network); !ok || name != Secp256k1Blake160 {
+			return false, fmt.Errorf("input_locks[%d] is not a sighash lock", group[0])
+		}
+
+		args, err := lock.Args.Raw()
+		if err != nil {
+			return false, fieldError(fmt.Sprintf("input_locks[%d].args", group[0]), err)
+		}
+
+		message, signature, err := t.sighashMessage(group, witnesses)
+		if err != nil {
+			return false, err
+		}
+
+		pub, err := secp256k1Recover(message, signature)
+		if err != nil {
+			return false, fieldError(fmt.Sprintf("witnesses[%d].lock", group[0]), err)
+		}
+
+		if !bytes.Equal(Blake160(pub), args) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
@@ -0,0 +1,113 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestVerifySighash(t *testing.T) {
+	tx, _ := loadSyntheticTransaction(t)
+	if tx == nil {
+		return
+	}
+
+	prev := tx.Inputs[0].PreviousOutput.TxHash
+	tx.Inputs = append(tx.Inputs,
+		CellInput{Since: "0x0", PreviousOutput: OutPoint{TxHash: prev, Index: "0x7"}},
+		CellInput{Since: "0x0", PreviousOutput: OutPoint{TxHash: prev, Index: "0x8"}},
+	)
+
+	// Inputs 0 and 2 are locked by bob, input 1 by alice
+	bob, _ := SighashLock(Mainnet, "0xc8328aabcd9b9e8e64fbc566c4385c3bdeb219d7")
+	alice, _ := SighashLock(Mainnet, "0x470dcdc5e44064909650113a274b3b36aecb6dc7")
+	locks := []*Script{bob, alice, bob}
+
+	// Signed by openssl pkeyutl with the well-known ckb dev chain keys
+	// 0xd00c06bf...93d2bc (bob) and 0x63d86723...53f24d (alice), s
+	// normalized to low s
+	witnesses := []Bytes{
+		"0x5500000010000000550000005500000041000000a02956549834791e69cce348d8b99b4d5dd777fdca1bbdac93e0678ec36e1f85290316f9fbddafa9e7c296e9ae32e3438b679f3e11888eca359ecba02b3e613000",
+		"0x5500000010000000550000005500000041000000ebd3fbd04c708a2149013535d9038a2b0792b29755b1f4e931f1289cc448b5ab1536073a3929fdf6c7e47f00dd5fa0f0a8aaea89ae76b44415d7c85e42ef40a501",
+		"0x",
+		"0x1234",
+	}
+
+	ok, err := tx.VerifySighash(Mainnet, locks, witnesses)
+	if err != nil {
+		t.Errorf("fail to verify sighash: %s\n", err)
+		return
+	}
+
+	if !ok {
+		t.Errorf("expect valid signatures")
+		return
+	}
+
+	// Witness beyond inputs is signed too
+	witnesses[3] = "0x1235"
+
+	ok, err = tx.VerifySighash(Mainnet, locks, witnesses)
+	if err != nil || ok {
+		t.Errorf("expect invalid signatures, got %v %v", ok, err)
+		return
+	}
+
+	// Swapped locks recover a different pubkey hash
+	witnesses[3] = "0x1234"
+
+	ok, err = tx.VerifySighash(Mainnet, []*Script{alice, bob, alice}, witnesses)
+	if err != nil || ok {
+		t.Errorf("expect invalid signatures, got %v %v", ok, err)
+		return
+	}
+
+	_, err = tx.VerifySighash(Mainnet, locks[:2], witnesses)
+	if err == nil {
+		t.Errorf("expect error on mismatched input locks")
+		return
+	}
+
+	// Recovery id out of range is a malformed signature, not a wrong signer
+	witnesses[1] = "0x5500000010000000550000005500000041000000ebd3fbd04c708a2149013535d9038a2b0792b29755b1f4e931f1289cc448b5ab1536073a3929fdf6c7e47f00dd5fa0f0a8aaea89ae76b44415d7c85e42ef40a504"
+
+	_, err = tx.VerifySighash(Mainnet, locks, witnesses)
+	if err == nil {
+		t.Errorf("expect error on malformed signature")
+		return
+	}
+}
+
+func TestGroupInputsByLock(t *testing.T) {
+	prev := Hash("0xee046ce2baeda575266d4164f394c53f66009f64759f7a9f12a014c692e79390")
+	tx := Transaction{
+		Inputs: []CellInput{
+			{Since: "0x0", PreviousOutput: OutPoint{TxHash: prev, Index: "0x6"}},
+			{Since: "0x0", PreviousOutput: OutPoint{TxHash: prev, Index: "0x7"}},
+			{Since: "0x0", PreviousOutput: OutPoint{TxHash: prev, Index: "0x8"}},
+		},
+	}
+
+	bob, _ := SighashLock(Mainnet, "0xc8328aabcd9b9e8e64fbc566c4385c3bdeb219d7")
+	alice, _ := SighashLock(Mainnet, "0x470dcdc5e44064909650113a274b3b36aecb6dc7")
+
+	groups, err := GroupInputsByLock(&tx, []*Script{bob, alice, bob})
+	if err != nil {
+		t.Errorf("fail to group inputs: %s\n", err)
+		return
+	}
+
+	expect := map[Hash][]int{
+		"0x32e555f3ff8e135cece1351a6a2971518392c1e30375c1e006ad0ce8eac07947": {0, 2},
+		"0xc219351b150b900e50a7039f1e448b844110927e5fd9bd30425806cb8ddff1fd": {1},
+	}
+	if !reflect.DeepEqual(groups, expect) {
+		t.Errorf("mismatch result, expect %v, got %v", expect, groups)
+		return
+	}
+
+	_, err = GroupInputsByLock(&tx, []*Script{bob})
+	if err == nil {
+		t.Errorf("expect error on mismatched input locks")
+		return
+	}
+}
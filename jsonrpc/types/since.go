@@ -0,0 +1,147 @@
+package types
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Since flags, see ckb rfc 0017
+/*
+ *     bit 63:      relative flag
+ *     bit 61 - 62: metric flag, 00 block number, 01 epoch, 10 timestamp
+ *     bit 56 - 60: reserved, must be zero
+ *     bit 0 - 55:  value
+ */
+const (
+	sinceRelativeFlag     uint64 = 1 << 63
+	sinceMetricBlock      uint64 = 0 << 61
+	sinceMetricEpoch      uint64 = 1 << 61
+	sinceMetricTimestamp  uint64 = 2 << 61
+	sinceValueMask        uint64 = 1<<56 - 1
+	epochNumberMask       uint64 = 1<<24 - 1
+	epochFractionMask     uint64 = 1<<16 - 1
+	epochIndexOffset             = 24
+	epochLengthOffset            = 40
+	sinceRelativeNotation        = "relative:"
+)
+
+// packSince pack since flags and value
+func packSince(relative bool, metric uint64, value uint64) (Uint64, error) {
+	if value&^sinceValueMask != 0 {
+		return "", fmt.Errorf("invalid since, value exceeds 56 bits")
+	}
+
+	since := metric | value
+	if relative {
+		since |= sinceRelativeFlag
+	}
+
+	return newUint64(since), nil
+}
+
+// packEpoch pack epoch number with fraction index/length
+func packEpoch(number, index, length uint64) (uint64, error) {
+	if number&^epochNumberMask != 0 {
+		return 0, fmt.Errorf("invalid epoch, number exceeds 24 bits")
+	}
+
+	if index&^epochFractionMask != 0 || length&^epochFractionMask != 0 {
+		return 0, fmt.Errorf("invalid epoch, fraction exceeds 16 bits")
+	}
+
+	if length == 0 || index >= length {
+		return 0, fmt.Errorf("invalid epoch, fraction %d/%d", index, length)
+	}
+
+	return number | index<<epochIndexOffset | length<<epochLengthOffset, nil
+}
+
+// parseEpochNotation parse epoch in "number" or "number+index/length" notation
+func parseEpochNotation(s string) (uint64, error) {
+	number, fraction := s, "0/1"
+	if i := strings.IndexByte(s, '+'); i >= 0 {
+		number, fraction = s[:i], s[i+1:]
+	}
+
+	parts := strings.Split(fraction, "/")
+	if len(parts) != 2 {
+		return 0, fmt.Errorf("invalid epoch %s, expect number+index/length", s)
+	}
+
+	n, err := strconv.ParseUint(number, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid epoch number %s", number)
+	}
+
+	index, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid epoch index %s", parts[0])
+	}
+
+	length, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid epoch length %s", parts[1])
+	}
+
+	return packEpoch(n, index, length)
+}
+
+// ParseSince parse since in human notation
+/*
+ * Supported notations:
+ *
+ *     block:12345
+ *     epoch:100
+ *     epoch:100+50/1000
+ *     timestamp:1700000000
+ *
+ * Prefix with "relative:" for relative since, e.g. relative:epoch:6.
+ */
+func ParseSince(s string) (Uint64, error) {
+	relative := strings.HasPrefix(s, sinceRelativeNotation)
+	notation := strings.TrimPrefix(s, sinceRelativeNotation)
+
+	i := strings.IndexByte(notation, ':')
+	if i < 0 {
+		return "", fmt.Errorf("invalid since %s, expect metric:value", s)
+	}
+
+	metric, value := notation[:i], notation[i+1:]
+
+	switch metric {
+	case "block":
+		n, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("invalid since block number %s", value)
+		}
+
+		return packSince(relative, sinceMetricBlock, n)
+	case "epoch":
+		e, err := parseEpochNotation(value)
+		if err != nil {
+			return "", err
+		}
+
+		return packSince(relative, sinceMetricEpoch, e)
+	case "timestamp":
+		n, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("invalid since timestamp %s", value)
+		}
+
+		return packSince(relative, sinceMetricTimestamp, n)
+	}
+
+	return "", fmt.Errorf("invalid since metric %s", metric)
+}
+
+// SinceRelativeEpochFraction relative epoch since of epochs plus index/length epoch
+func SinceRelativeEpochFraction(epochs uint64, index, length uint16) (Uint64, error) {
+	e, err := packEpoch(epochs, uint64(index), uint64(length))
+	if err != nil {
+		return "", err
+	}
+
+	return packSince(true, sinceMetricEpoch, e)
+}
@@ -0,0 +1,74 @@
+package types
+
+import (
+	"testing"
+)
+
+func TestParseSince(t *testing.T) {
+	tests := []struct {
+		notation string
+		expect   Uint64
+	}{
+		{"block:12345", "0x3039"},
+		{"relative:block:100", "0x8000000000000064"},
+		{"epoch:100", "0x2000010000000064"},
+		{"epoch:100+50/1000", "0x2003e80032000064"},
+		{"relative:epoch:100+50/1000", "0xa003e80032000064"},
+		{"timestamp:1700000000", "0x400000006553f100"},
+	}
+
+	for _, test := range tests {
+		got, err := ParseSince(test.notation)
+		if err != nil {
+			t.Errorf("fail to parse %s: %s\n", test.notation, err)
+			return
+		}
+
+		if got != test.expect {
+			t.Errorf("mismatch result of %s, expect %v, got %v", test.notation, test.expect, got)
+			return
+		}
+	}
+
+	invalid := []string{"12345", "height:1", "epoch:1+2/2", "epoch:1+1/0", "block:72057594037927936"}
+	for _, notation := range invalid {
+		_, err := ParseSince(notation)
+		if err == nil {
+			t.Errorf("expect error on %s", notation)
+			return
+		}
+	}
+}
+
+func TestSinceRelativeEpochFraction(t *testing.T) {
+	got, err := SinceRelativeEpochFraction(100, 50, 1000)
+	if err != nil || got != "0xa003e80032000064" {
+		t.Errorf("mismatch result, expect %v, got %v (%v)", "0xa003e80032000064", got, err)
+		return
+	}
+
+	expect, _ := ParseSince("relative:epoch:6+0/1")
+	got, err = SinceRelativeEpochFraction(6, 0, 1)
+	if err != nil || got != expect {
+		t.Errorf("mismatch result, expect %v, got %v (%v)", expect, got, err)
+		return
+	}
+
+	_, err = SinceRelativeEpochFraction(6, 0, 0)
+	if err == nil {
+		t.Errorf("expect error on zero length")
+		return
+	}
+
+	_, err = SinceRelativeEpochFraction(6, 10, 10)
+	if err == nil {
+		t.Errorf("expect error on index not less than length")
+		return
+	}
+
+	_, err = SinceRelativeEpochFraction(1<<24, 0, 1)
+	if err == nil {
+		t.Errorf("expect error on epoch number exceeds 24 bits")
+		return
+	}
+}
@@ -0,0 +1,208 @@
+package types
+
+import (
+	"encoding/hex"
+	"fmt"
+)
+
+// hexLen decoded length of 0x-prefix hex string, without decoding it
+func hexLen(s string) (int, error) {
+	err := check0xPrefix(s)
+	if err != nil {
+		return 0, err
+	}
+
+	s = s[2:]
+	if len(s)%2 != 0 {
+		return 0, hex.ErrLength
+	}
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F') {
+			return 0, hex.InvalidByteError(c)
+		}
+	}
+
+	return len(s) / 2, nil
+}
+
+// rawHexLen decoded length of hex string, assuming it is valid 0x-prefix hex
+func rawHexLen(s string) int {
+	if len(s) < 2 {
+		return 0
+	}
+
+	return (len(s) - 2) / 2
+}
+
+// tableLen size of table or dynvec from its items size
+func tableLen(count int, itemsLen int) int {
+	return int(u32Size) + int(u32Size)*count + itemsLen
+}
+
+func (h *Hash) serializedLen() (int, error) {
+	n, err := hexLen(string(*h))
+	if err != nil {
+		return 0, err
+	}
+
+	if n != hashSize {
+		return 0, fmt.Errorf("invalid hash, should be 32 bytes")
+	}
+
+	return hashSize, nil
+}
+
+func (b *Bytes) serializedLen() (int, error) {
+	n, err := hexLen(string(*b))
+	if err != nil {
+		return 0, err
+	}
+
+	return int(u32Size) + n, nil
+}
+
+func (o *OutPoint) serializedLen() (int, error) {
+	if _, err := o.TxHash.serializedLen(); err != nil {
+		return 0, fieldError("tx_hash", err)
+	}
+
+	if _, err := o.Index.Uint32(); err != nil {
+		return 0, fieldError("index", err)
+	}
+
+	return outPointSize, nil
+}
+
+// SerializedSize serialized script size, without serializing it
+/*
+ * A script is a table of code hash, hash type and args:
+ *
+ *     4 total size + 12 offsets + 32 code hash + 1 hash type + 4 + len(args)
+ */
+func (s *Script) SerializedSize() (int, error) {
+	if _, err := s.CodeHash.serializedLen(); err != nil {
+		return 0, fieldError("code_hash", err)
+	}
+
+	if s.HashType != Data && s.HashType != Type && s.HashType != Data1 {
+		return 0, fieldError("hash_type", fmt.Errorf("invalid script hash type"))
+	}
+
+	a, err := s.Args.serializedLen()
+	if err != nil {
+		return 0, fieldError("args", err)
+	}
+
+	return tableLen(3, hashSize+1+a), nil
+}
+
+// rawLen serialized script size, assuming the script is valid
+func (s *Script) rawLen() int {
+	return tableLen(3, hashSize+1+int(u32Size)+rawHexLen(string(s.Args)))
+}
+
+// rawLen serialized cell output size, assuming the output is valid
+/*
+ * Serialize validates the output anyway, so pre-sizing the outputs
+ * dynvec doesn't have to validate it twice.
+ */
+func (o *CellOutput) rawLen() int {
+	var t int
+	if o.Type != nil {
+		t = o.Type.rawLen()
+	}
+
+	return tableLen(3, 8+o.Lock.rawLen()+t)
+}
+
+// SerializedLen serialized cell output size, without serializing it
+func (o *CellOutput) SerializedLen() (int, error) {
+	if _, err := o.Capacity.Uint64(); err != nil {
+		return 0, fieldError("capacity", err)
+	}
+
+	l, err := o.Lock.SerializedSize()
+	if err != nil {
+		return 0, fieldError("lock", err)
+	}
+
+	var t int
+	if o.Type != nil {
+		t, err = o.Type.SerializedSize()
+		if err != nil {
+			return 0, fieldError("type", err)
+		}
+	}
+
+	return tableLen(3, 8+l+t), nil
+}
+
+// SerializedLen serialized raw transaction size, without serializing it
+/*
+ * Computes the same size as len(Serialize()) by molecule offset math:
+ *
+ *     fixvec: 4 + count * item size
+ *     table and dynvec: 4 + 4 * count + items size
+ */
+func (t *Transaction) SerializedLen() (int, error) {
+	if _, err := t.Version.Uint32(); err != nil {
+		return 0, fieldError("version", err)
+	}
+
+	for i := 0; i < len(t.CellDeps); i++ {
+		if _, err := t.CellDeps[i].OutPoint.serializedLen(); err != nil {
+			return 0, fieldError(fmt.Sprintf("cell_deps[%d].out_point", i), err)
+		}
+
+		if t.CellDeps[i].DepType != Code && t.CellDeps[i].DepType != DepGroup {
+			return 0, fieldError(fmt.Sprintf("cell_deps[%d].dep_type", i), fmt.Errorf("invalid dep group"))
+		}
+	}
+
+	for i := 0; i < len(t.HeaderDeps); i++ {
+		if _, err := t.HeaderDeps[i].serializedLen(); err != nil {
+			return 0, fieldError(fmt.Sprintf("header_deps[%d]", i), err)
+		}
+	}
+
+	for i := 0; i < len(t.Inputs); i++ {
+		if _, err := t.Inputs[i].Since.Uint64(); err != nil {
+			return 0, fieldError(fmt.Sprintf("inputs[%d].since", i), err)
+		}
+
+		if _, err := t.Inputs[i].PreviousOutput.serializedLen(); err != nil {
+			return 0, fieldError(fmt.Sprintf("inputs[%d].previous_output", i), err)
+		}
+	}
+
+	var ops int
+	for i := 0; i < len(t.Outputs); i++ {
+		n, err := t.Outputs[i].SerializedLen()
+		if err != nil {
+			return 0, fieldError(fmt.Sprintf("outputs[%d]", i), err)
+		}
+
+		ops += n
+	}
+
+	var ods int
+	for i := 0; i < len(t.OutputsData); i++ {
+		n, err := t.OutputsData[i].serializedLen()
+		if err != nil {
+			return 0, fieldError(fmt.Sprintf("outputs_data[%d]", i), err)
+		}
+
+		ods += n
+	}
+
+	fields := 4 +
+		int(u32Size) + cellDepSize*len(t.CellDeps) +
+		int(u32Size) + hashSize*len(t.HeaderDeps) +
+		int(u32Size) + cellInputSize*len(t.Inputs) +
+		tableLen(len(t.Outputs), ops) +
+		tableLen(len(t.OutputsData), ods)
+
+	return tableLen(6, fields), nil
+}
@@ -0,0 +1,81 @@
+package types
+
+import (
+	"testing"
+)
+
+func TestSerializedLen(t *testing.T) {
+	tx, _ := loadSyntheticTransaction(t)
+	if tx == nil {
+		return
+	}
+
+	txs := []Transaction{*tx, {Version: "0x0"}}
+
+	for _, tx := range txs {
+		b, err := tx.Serialize()
+		if err != nil {
+			t.Errorf("fail to serialize: %s\n", err)
+			return
+		}
+
+		got, err := tx.SerializedLen()
+		if err != nil {
+			t.Errorf("fail to compute serialized len: %s\n", err)
+			return
+		}
+
+		if got != len(b) {
+			t.Errorf("mismatch result, expect %v, got %v", len(b), got)
+			return
+		}
+	}
+
+	allocs := testing.AllocsPerRun(10, func() {
+		tx.SerializedLen()
+	})
+	if allocs != 0 {
+		t.Errorf("mismatch allocations, expect 0, got %v", allocs)
+		return
+	}
+
+	tx.Outputs[1].Lock.Args = "0x123"
+
+	_, err := tx.SerializedLen()
+	if err == nil {
+		t.Errorf("expect error on invalid args")
+		return
+	}
+}
+
+func TestScriptSerializedSize(t *testing.T) {
+	s := Script{
+		CodeHash: "0x9bd7e06f3ecf4be0f2fcd2188b23f1b9fcc88e5d4b65a8637b17723bbda3cce8",
+		HashType: Type,
+		Args:     "0xc8328aabcd9b9e8e64fbc566c4385c3bdeb219d7",
+	}
+
+	b, err := s.Serialize()
+	if err != nil {
+		t.Errorf("fail to serialize: %s\n", err)
+		return
+	}
+
+	n, err := s.SerializedSize()
+	if err != nil {
+		t.Errorf("fail to compute serialized size: %s\n", err)
+		return
+	}
+
+	if n != len(b) || n != 4+12+32+1+4+20 {
+		t.Errorf("mismatch result, expect %v, got %v", len(b), n)
+		return
+	}
+
+	s.Args = "0xc8328"
+	_, err = s.SerializedSize()
+	if err == nil {
+		t.Errorf("expect error on odd length args")
+		return
+	}
+}
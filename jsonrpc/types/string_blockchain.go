@@ -0,0 +1,30 @@
+package types
+
+import (
+	"fmt"
+)
+
+// String script
+func (s *Script) String() string {
+	return fmt.Sprintf("Script{code_hash: %s, hash_type: %s, args: %s}", s.CodeHash, s.HashType, s.Args)
+}
+
+// String outpoint
+func (o *OutPoint) String() string {
+	return fmt.Sprintf("OutPoint{tx_hash: %s, index: %s}", o.TxHash, o.Index)
+}
+
+// String cell input
+func (i *CellInput) String() string {
+	return fmt.Sprintf("CellInput{since: %s, previous_output: %s}", i.Since, i.PreviousOutput.String())
+}
+
+// String cell output
+func (o *CellOutput) String() string {
+	t := "nil"
+	if o.Type != nil {
+		t = o.Type.String()
+	}
+
+	return fmt.Sprintf("CellOutput{capacity: %s, lock: %s, type: %s}", o.Capacity, o.Lock.String(), t)
+}
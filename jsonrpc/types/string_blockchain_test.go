@@ -0,0 +1,25 @@
+package types
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestStringCellOutput(t *testing.T) {
+	o := CellOutput{
+		Capacity: "0x666",
+		Lock: Script{
+			CodeHash: "0xe49352ee4984694d88eb3c1493a33d69d61c786dc5b0a32c4b3978d4fad64379",
+			HashType: Type,
+			Args:     "0x470dcdc5e44064909650113a274b3b36aecb6dc7",
+		},
+	}
+
+	expect := "CellOutput{capacity: 0x666, lock: Script{code_hash: 0xe49352ee4984694d88eb3c1493a33d69d61c786dc5b0a32c4b3978d4fad64379, hash_type: type, args: 0x470dcdc5e44064909650113a274b3b36aecb6dc7}, type: nil}"
+
+	got := fmt.Sprintf("%v", &o)
+	if got != expect {
+		t.Errorf("mismatch result, expect %v, got %v", expect, got)
+		return
+	}
+}
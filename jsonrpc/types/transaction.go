@@ -0,0 +1,362 @@
+package types
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// SetVersion set transaction version
+func (t *Transaction) SetVersion(v uint32) {
+	t.Version = newUint32(v)
+}
+
+// SetVersionStrict set transaction version, only version 0 is supported by ckb
+func (t *Transaction) SetVersionStrict(v uint32) error {
+	if v != 0 {
+		return fmt.Errorf("unsupported transaction version %d", v)
+	}
+
+	t.SetVersion(v)
+
+	return nil
+}
+
+// TotalOutputCapacity sum of all outputs capacity in shannon
+func (t *Transaction) TotalOutputCapacity() (uint64, error) {
+	var total uint64
+
+	for i := 0; i < len(t.Outputs); i++ {
+		c, err := t.Outputs[i].Capacity.Uint64()
+		if err != nil {
+			return 0, fmt.Errorf("invalid outputs[%d] capacity: %s", i, err)
+		}
+
+		var carry uint64
+		total, carry = bits.Add64(total, c, 0)
+		if carry != 0 {
+			return 0, fmt.Errorf("total output capacity overflow")
+		}
+	}
+
+	return total, nil
+}
+
+// Fee transaction fee in shannon, inputCapacities are resolved capacities of inputs
+func (t *Transaction) Fee(inputCapacities []uint64) (uint64, error) {
+	if len(inputCapacities) != len(t.Inputs) {
+		return 0, fmt.Errorf("mismatch input capacities, expect %d, got %d", len(t.Inputs), len(inputCapacities))
+	}
+
+	var inputs uint64
+	for i := 0; i < len(inputCapacities); i++ {
+		var carry uint64
+		inputs, carry = bits.Add64(inputs, inputCapacities[i], 0)
+		if carry != 0 {
+			return 0, fmt.Errorf("total input capacity overflow")
+		}
+	}
+
+	outputs, err := t.TotalOutputCapacity()
+	if err != nil {
+		return 0, err
+	}
+
+	if outputs > inputs {
+		return 0, fmt.Errorf("outputs capacity %d exceeds inputs capacity %d", outputs, inputs)
+	}
+
+	return inputs - outputs, nil
+}
+
+// FindDuplicateInputs find inputs spending an already spent outpoint
+/*
+ * Returns the previous outputs of every repeated input, the first
+ * occurrence of an outpoint is not included.
+ */
+func (t *Transaction) FindDuplicateInputs() []*OutPoint {
+	seen := make(map[string]bool, len(t.Inputs))
+	dups := make([]*OutPoint, 0)
+
+	for i := 0; i < len(t.Inputs); i++ {
+		o := &t.Inputs[i].PreviousOutput
+
+		k := o.key()
+		if seen[k] {
+			dups = append(dups, o)
+			continue
+		}
+
+		seen[k] = true
+	}
+
+	return dups
+}
+
+// HasDuplicateInputs whether any outpoint is spent more than once
+func (t *Transaction) HasDuplicateInputs() bool {
+	return len(t.FindDuplicateInputs()) != 0
+}
+
+// AddCellDep append cell dep unless an equal one is already present
+func (t *Transaction) AddCellDep(dep *CellDep) {
+	for i := 0; i < len(t.CellDeps); i++ {
+		if t.CellDeps[i].Equal(dep) {
+			return
+		}
+	}
+
+	t.CellDeps = append(t.CellDeps, *dep)
+}
+
+// AddHeaderDep append header dep unless an equal one is already present
+func (t *Transaction) AddHeaderDep(h Hash) {
+	for i := 0; i < len(t.HeaderDeps); i++ {
+		if t.HeaderDeps[i].Equal(h) {
+			return
+		}
+	}
+
+	t.HeaderDeps = append(t.HeaderDeps, h)
+}
+
+// HeaderDepHashes copy of header dep hashes
+func (t *Transaction) HeaderDepHashes() []Hash {
+	return append([]Hash{}, t.HeaderDeps...)
+}
+
+// CellDepOutPoints copies of outpoints of all cell deps
+func (t *Transaction) CellDepOutPoints() []*OutPoint {
+	if t == nil {
+		return nil
+	}
+
+	outPoints := make([]*OutPoint, len(t.CellDeps))
+	for i := 0; i < len(t.CellDeps); i++ {
+		o := t.CellDeps[i].OutPoint
+		outPoints[i] = &o
+	}
+
+	return outPoints
+}
+
+// MergeDeps union of cell deps and header deps of a and b, in order of first appearance
+/*
+ * Duplicates are dropped by CellDep.Equal and Hash.Equal, also within a
+ * single transaction. Returned cell deps are copies.
+ */
+func MergeDeps(a, b *Transaction) (cellDeps []*CellDep, headerDeps []Hash) {
+	var merged Transaction
+	for _, t := range []*Transaction{a, b} {
+		for i := 0; i < len(t.CellDeps); i++ {
+			merged.AddCellDep(&t.CellDeps[i])
+		}
+
+		for i := 0; i < len(t.HeaderDeps); i++ {
+			merged.AddHeaderDep(t.HeaderDeps[i])
+		}
+	}
+
+	cellDeps = make([]*CellDep, len(merged.CellDeps))
+	for i := 0; i < len(merged.CellDeps); i++ {
+		cellDeps[i] = &merged.CellDeps[i]
+	}
+
+	return cellDeps, merged.HeaderDepHashes()
+}
+
+// Hash transaction hash, blake2b-256 of the serialized raw transaction
+func (t *Transaction) Hash() (Hash, error) {
+	b, err := t.Serialize()
+	if err != nil {
+		return "", err
+	}
+
+	return CKBHash(b), nil
+}
+
+// Summary one line summary for logging
+func (t *Transaction) Summary() string {
+	if t == nil {
+		return "Transaction{nil}"
+	}
+
+	capacity := "invalid"
+	if c, err := t.TotalOutputCapacity(); err == nil {
+		capacity = ShannonToCKB(c) + " CKB"
+	}
+
+	hash := "invalid"
+	if h, err := t.Hash(); err == nil {
+		hash = string(h)
+	}
+
+	return fmt.Sprintf("Transaction{hash: %s, inputs: %d, outputs: %d, cell_deps: %d, capacity: %s}",
+		hash, len(t.Inputs), len(t.Outputs), len(t.CellDeps), capacity)
+}
+
+// CachedTransaction transaction with its raw serialization memoized
+/*
+ * The cache is kept out of Transaction, so copies and comparisons of
+ * transactions aren't affected by it. Tx isn't tracked, callers mutating
+ * it after a cached serialize must call Invalidate, otherwise stale
+ * bytes are returned.
+ */
+type CachedTransaction struct {
+	Tx  *Transaction
+	raw []byte
+}
+
+// NewCachedTransaction wrap tx with an empty cache
+func NewCachedTransaction(tx *Transaction) *CachedTransaction {
+	return &CachedTransaction{Tx: tx}
+}
+
+// Serialize serialize raw transaction, reuse the cached bytes if any
+/*
+ * The returned bytes are shared and must not be modified.
+ */
+func (c *CachedTransaction) Serialize() ([]byte, error) {
+	if c.raw != nil {
+		return c.raw, nil
+	}
+
+	b, err := c.Tx.Serialize()
+	if err != nil {
+		return nil, err
+	}
+
+	c.raw = b
+
+	return b, nil
+}
+
+// Hash transaction hash of the cached raw transaction
+func (c *CachedTransaction) Hash() (Hash, error) {
+	b, err := c.Serialize()
+	if err != nil {
+		return "", err
+	}
+
+	return CKBHash(b), nil
+}
+
+// Invalidate drop cached serialized bytes
+func (c *CachedTransaction) Invalidate() {
+	c.raw = nil
+}
+
+// AddOutput append output along with its data
+func (t *Transaction) AddOutput(output *CellOutput, data Bytes) {
+	t.Outputs = append(t.Outputs, *output)
+	t.OutputsData = append(t.OutputsData, data)
+}
+
+// ReplaceInput replace input at index i
+func (t *Transaction) ReplaceInput(i int, in *CellInput) error {
+	if i < 0 || i >= len(t.Inputs) {
+		return fmt.Errorf("input index %d out of range, %d inputs", i, len(t.Inputs))
+	}
+
+	t.Inputs[i] = *in
+
+	return nil
+}
+
+// ReplaceOutput replace output and its data at index i
+func (t *Transaction) ReplaceOutput(i int, out *CellOutput, data Bytes) error {
+	if len(t.Outputs) != len(t.OutputsData) {
+		return fmt.Errorf("outputs and outputs data length mismatch, %d != %d", len(t.Outputs), len(t.OutputsData))
+	}
+
+	if i < 0 || i >= len(t.Outputs) {
+		return fmt.Errorf("output index %d out of range, %d outputs", i, len(t.Outputs))
+	}
+
+	t.Outputs[i] = *out
+	t.OutputsData[i] = data
+
+	return nil
+}
+
+// AddChangeOutput append change output locked by lock
+/*
+ * The change capacity is inputCapacity - sum(outputs) - fee, it must be
+ * enough to hold the change cell itself.
+ */
+func (t *Transaction) AddChangeOutput(lock *Script, inputCapacity uint64, fee uint64) error {
+	outputs, err := t.TotalOutputCapacity()
+	if err != nil {
+		return err
+	}
+
+	spent, carry := bits.Add64(outputs, fee, 0)
+	if carry != 0 || spent > inputCapacity {
+		return fmt.Errorf("insufficient input capacity %d for outputs %d and fee %d", inputCapacity, outputs, fee)
+	}
+
+	change := &CellOutput{
+		Capacity: newUint64(inputCapacity - spent),
+		Lock:     *lock,
+	}
+
+	occupied, err := change.OccupiedCapacity()
+	if err != nil {
+		return err
+	}
+
+	if inputCapacity-spent < occupied {
+		return fmt.Errorf("change capacity %d less than occupied capacity %d", inputCapacity-spent, occupied)
+	}
+
+	t.AddOutput(change, EmptyBytes())
+
+	return nil
+}
+
+// ExceedsMaxSize whether full serialized size exceeds maxBytes
+/*
+ * Witnesses are used in place of t.Witnesses, so the size can be checked
+ * with placeholder witnesses before signing. Returns the actual size.
+ */
+func (t *Transaction) ExceedsMaxSize(witnesses []Bytes, maxBytes int) (bool, int, error) {
+	b, err := t.fullSerialize(witnesses)
+	if err != nil {
+		return false, 0, err
+	}
+
+	return len(b) > maxBytes, len(b), nil
+}
+
+// OutputLockHashes lock script hash of each output
+func (t *Transaction) OutputLockHashes() ([]Hash, error) {
+	hashes := make([]Hash, len(t.Outputs))
+	for i := 0; i < len(t.Outputs); i++ {
+		h, err := t.Outputs[i].Lock.Hash()
+		if err != nil {
+			return nil, fieldError(fmt.Sprintf("outputs[%d].lock", i), err)
+		}
+
+		hashes[i] = h
+	}
+
+	return hashes, nil
+}
+
+// OutputTypeHashes type script hash of each output, outputs without type are skipped
+func (t *Transaction) OutputTypeHashes() ([]Hash, error) {
+	hashes := make([]Hash, 0, len(t.Outputs))
+	for i := 0; i < len(t.Outputs); i++ {
+		if t.Outputs[i].Type == nil {
+			continue
+		}
+
+		h, err := t.Outputs[i].Type.Hash()
+		if err != nil {
+			return nil, fieldError(fmt.Sprintf("outputs[%d].type", i), err)
+		}
+
+		hashes = append(hashes, h)
+	}
+
+	return hashes, nil
+}
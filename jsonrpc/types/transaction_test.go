@@ -0,0 +1,557 @@
+package types
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTotalOutputCapacity(t *testing.T) {
+	tx := Transaction{
+		Outputs: []CellOutput{
+			{Capacity: "0x1c6bf52634000"},
+			{Capacity: "0x1bda703f0a000"},
+		},
+	}
+
+	got, err := tx.TotalOutputCapacity()
+	if err != nil {
+		t.Errorf("fail to sum capacity: %s\n", err)
+		return
+	}
+
+	if got != 990000000000000 {
+		t.Errorf("mismatch result, expect %v, got %v", 990000000000000, got)
+		return
+	}
+
+	tx.Outputs = append(tx.Outputs, CellOutput{Capacity: "0xffffffffffffffff"})
+
+	_, err = tx.TotalOutputCapacity()
+	if err == nil {
+		t.Errorf("expect error on capacity overflow")
+		return
+	}
+}
+
+// loadSyntheticTransaction load transaction and its hash from testdata/synthetic_transaction.json
+/*
+ * The payload is synthetic, not captured from a node. It is shaped like a
+ * get_transaction response, and its hash was computed with a separate
+ * molecule encoder and blake2b, not with this package.
+ */
+func loadSyntheticTransaction(t *testing.T) (*Transaction, Hash) {
+	t.Helper()
+
+	raw, err := ioutil.ReadFile(filepath.Join("testdata", "synthetic_transaction.json"))
+	if err != nil {
+		t.Errorf("fail to read rpc payload: %s\n", err)
+		return nil, ""
+	}
+
+	var resp struct {
+		Result struct {
+			Transaction struct {
+				Transaction
+				Hash Hash `json:"hash"`
+			} `json:"transaction"`
+		} `json:"result"`
+	}
+
+	err = json.Unmarshal(raw, &resp)
+	if err != nil {
+		t.Errorf("fail to unmarshal rpc payload: %s\n", err)
+		return nil, ""
+	}
+
+	return &resp.Result.Transaction.Transaction, resp.Result.Transaction.Hash
+}
+
+func TestSyntheticTransactionHash(t *testing.T) {
+	tx, hash := loadSyntheticTransaction(t)
+	if tx == nil {
+		return
+	}
+
+	if len(tx.Witnesses) != 1 || len(tx.CellDeps) != 2 || tx.Outputs[0].Type == nil {
+		t.Errorf("unexpected parsed transaction %v", *tx)
+		return
+	}
+
+	b, err := tx.Serialize()
+	if err != nil {
+		t.Errorf("fail to serialize: %s\n", err)
+		return
+	}
+
+	got := CKBHash(b)
+	if got != hash {
+		t.Errorf("mismatch hash, expect %v, got %v", hash, got)
+		return
+	}
+}
+
+func TestFindDuplicateInputs(t *testing.T) {
+	txHash := "0xee046ce2baeda575266d4164f394c53f66009f64759f7a9f12a014c692e79390"
+
+	tx := Transaction{
+		Inputs: []CellInput{
+			{Since: "0x0", PreviousOutput: OutPoint{TxHash: Hash(txHash), Index: "0x6"}},
+			{Since: "0x0", PreviousOutput: OutPoint{TxHash: Hash(txHash), Index: "0x7"}},
+		},
+	}
+
+	if tx.HasDuplicateInputs() {
+		t.Errorf("unexpected duplicate inputs")
+		return
+	}
+
+	// Same outpoint with different casing and index padding
+	tx.Inputs = append(tx.Inputs, CellInput{
+		Since:          "0x0",
+		PreviousOutput: OutPoint{TxHash: Hash("0x" + strings.ToUpper(txHash[2:])), Index: "0x06"},
+	})
+
+	dups := tx.FindDuplicateInputs()
+	if len(dups) != 1 || dups[0] != &tx.Inputs[2].PreviousOutput {
+		t.Errorf("mismatch duplicate inputs, got %v", dups)
+		return
+	}
+}
+
+func TestAddCellDep(t *testing.T) {
+	sighash := CellDep{
+		OutPoint: OutPoint{TxHash: "0xb815a396c5226009670e89ee514850dcde452bca746cdd6b41c104b50e559c70", Index: "0x0"},
+		DepType:  DepGroup,
+	}
+
+	var tx Transaction
+
+	tx.AddCellDep(&sighash)
+	tx.AddCellDep(&CellDep{
+		OutPoint: OutPoint{TxHash: "0xB815A396C5226009670E89EE514850DCDE452BCA746CDD6B41C104B50E559C70", Index: "0x00"},
+		DepType:  DepGroup,
+	})
+
+	if len(tx.CellDeps) != 1 {
+		t.Errorf("mismatch cell deps, expect 1, got %v", len(tx.CellDeps))
+		return
+	}
+
+	// Same outpoint with different dep type is another dep
+	tx.AddCellDep(&CellDep{OutPoint: sighash.OutPoint, DepType: Code})
+
+	if len(tx.CellDeps) != 2 {
+		t.Errorf("mismatch cell deps, expect 2, got %v", len(tx.CellDeps))
+		return
+	}
+}
+
+func TestTransactionSummary(t *testing.T) {
+	var tx *Transaction
+
+	if tx.Summary() != "Transaction{nil}" {
+		t.Errorf("mismatch nil summary, got %v", tx.Summary())
+		return
+	}
+
+	raw, err := ioutil.ReadFile(filepath.Join("testdata", "ckbvectors", "transfer.json"))
+	if err != nil {
+		t.Errorf("fail to read vector: %s\n", err)
+		return
+	}
+
+	var v struct {
+		Transaction Transaction `json:"transaction"`
+	}
+
+	err = json.Unmarshal(raw, &v)
+	if err != nil {
+		t.Errorf("fail to unmarshal vector: %s\n", err)
+		return
+	}
+
+	expect := "Transaction{hash: 0x1bba484779516044d021b1219c63d011ca571e418155ba1443275a4cf13e0498, inputs: 1, outputs: 2, cell_deps: 1, capacity: 10000000 CKB}"
+
+	got := v.Transaction.Summary()
+	if got != expect {
+		t.Errorf("mismatch summary, expect %v, got %v", expect, got)
+		return
+	}
+}
+
+func TestCachedTransaction(t *testing.T) {
+	tx := NewTransaction()
+	before := *tx
+
+	c := NewCachedTransaction(tx)
+
+	first, err := c.Serialize()
+	if err != nil {
+		t.Errorf("fail to serialize: %s\n", err)
+		return
+	}
+
+	// Cache lives in the wrapper, tx isn't touched
+	if !reflect.DeepEqual(before, *tx) {
+		t.Errorf("expect transaction unchanged by cached serialize")
+		return
+	}
+
+	// Mutation without invalidate still returns cached bytes
+	tx.SetVersion(1)
+
+	got, _ := c.Serialize()
+	if !bytes.Equal(got, first) {
+		t.Errorf("expect cached bytes, got %x", got)
+		return
+	}
+
+	c.Invalidate()
+
+	got, _ = c.Serialize()
+	expect, _ := tx.Serialize()
+	if !bytes.Equal(got, expect) || bytes.Equal(got, first) {
+		t.Errorf("expect fresh bytes after invalidate, got %x", got)
+		return
+	}
+
+	h, err := c.Hash()
+	if err != nil || h != CKBHash(expect) {
+		t.Errorf("mismatch result, expect %v, got %v (%v)", CKBHash(expect), h, err)
+		return
+	}
+}
+
+func TestAddChangeOutput(t *testing.T) {
+	lock := Script{
+		CodeHash: "0x9bd7e06f3ecf4be0f2fcd2188b23f1b9fcc88e5d4b65a8637b17723bbda3cce8",
+		HashType: Type,
+		Args:     "0xc8328aabcd9b9e8e64fbc566c4385c3bdeb219d7",
+	}
+
+	var tx Transaction
+	tx.AddOutput(&CellOutput{Capacity: "0x174876e800", Lock: lock}, EmptyBytes())
+
+	// 1000 ckb in, 1000 ckb out, no room for change
+	err := tx.AddChangeOutput(&lock, 100000000000, 1000)
+	if err == nil {
+		t.Errorf("expect error on insufficient capacity")
+		return
+	}
+
+	// 1060 ckb in, change 60 ckb - fee is less than 61 ckb occupied
+	err = tx.AddChangeOutput(&lock, 106000000000, 1000)
+	if err == nil {
+		t.Errorf("expect error on change below occupied capacity")
+		return
+	}
+
+	err = tx.AddChangeOutput(&lock, 110000000000, 1000)
+	if err != nil {
+		t.Errorf("fail to add change output: %s\n", err)
+		return
+	}
+
+	if len(tx.Outputs) != 2 || len(tx.OutputsData) != 2 || tx.Outputs[1].Capacity != newUint64(9999999000) {
+		t.Errorf("mismatch change output, got %v", tx.Outputs)
+		return
+	}
+}
+
+func TestExceedsMaxSize(t *testing.T) {
+	tx, _ := loadSyntheticTransaction(t)
+	if tx == nil {
+		return
+	}
+
+	full, err := tx.FullSerialize()
+	if err != nil {
+		t.Errorf("fail to full serialize: %s\n", err)
+		return
+	}
+
+	exceeds, size, err := tx.ExceedsMaxSize(tx.Witnesses, len(full))
+	if err != nil {
+		t.Errorf("fail to check size: %s\n", err)
+		return
+	}
+
+	if exceeds || size != len(full) {
+		t.Errorf("mismatch result, expect %v %v, got %v %v", false, len(full), exceeds, size)
+		return
+	}
+
+	exceeds, _, _ = tx.ExceedsMaxSize(tx.Witnesses, len(full)-1)
+	if !exceeds {
+		t.Errorf("expect exceeds with limit %d", len(full)-1)
+		return
+	}
+
+	// One more witness adds its bytes plus a dynvec offset
+	witnesses := append(tx.Witnesses, Bytes("0x1234"))
+
+	_, size, err = tx.ExceedsMaxSize(witnesses, len(full))
+	if err != nil {
+		t.Errorf("fail to check size: %s\n", err)
+		return
+	}
+
+	if size != len(full)+4+4+2 {
+		t.Errorf("mismatch size, expect %v, got %v", len(full)+10, size)
+		return
+	}
+}
+
+func TestOutputScriptHashes(t *testing.T) {
+	tx, _ := loadSyntheticTransaction(t)
+	if tx == nil {
+		return
+	}
+
+	locks, err := tx.OutputLockHashes()
+	if err != nil {
+		t.Errorf("fail to hash locks: %s\n", err)
+		return
+	}
+
+	expectLocks := []Hash{
+		"0xc219351b150b900e50a7039f1e448b844110927e5fd9bd30425806cb8ddff1fd",
+		"0x32e555f3ff8e135cece1351a6a2971518392c1e30375c1e006ad0ce8eac07947",
+	}
+	if !reflect.DeepEqual(locks, expectLocks) {
+		t.Errorf("mismatch result, expect %v, got %v", expectLocks, locks)
+		return
+	}
+
+	types, err := tx.OutputTypeHashes()
+	if err != nil {
+		t.Errorf("fail to hash types: %s\n", err)
+		return
+	}
+
+	expectTypes := []Hash{"0xcc77c4deac05d68ab5b26828f0bf4565a8d73113d7bb7e92b8362b8a74e58e58"}
+	if !reflect.DeepEqual(types, expectTypes) {
+		t.Errorf("mismatch result, expect %v, got %v", expectTypes, types)
+		return
+	}
+}
+
+func TestTransactionHeaderDeps(t *testing.T) {
+	o, _ := NewOutPoint("0xee046ce2baeda575266d4164f394c53f66009f64759f7a9f12a014c692e79390", 6)
+	lock, _ := SighashLock(Mainnet, "0xc8328aabcd9b9e8e64fbc566c4385c3bdeb219d7")
+
+	tx := Transaction{Version: "0x0", Inputs: []CellInput{*NewCellInput(o)}}
+	tx.AddOutput(&CellOutput{Capacity: "0x1c6bf52634000", Lock: *lock}, EmptyBytes())
+
+	tx.AddHeaderDep("0xaa00000000000000000000000000000000000000000000000000000000000001")
+	tx.AddHeaderDep("0xbb00000000000000000000000000000000000000000000000000000000000002")
+	tx.AddHeaderDep("0xAA00000000000000000000000000000000000000000000000000000000000001")
+
+	expect := []Hash{
+		"0xaa00000000000000000000000000000000000000000000000000000000000001",
+		"0xbb00000000000000000000000000000000000000000000000000000000000002",
+	}
+
+	got := tx.HeaderDepHashes()
+	if !reflect.DeepEqual(expect, got) {
+		t.Errorf("mismatch header deps, expect %v, got %v", expect, got)
+		return
+	}
+
+	expectHex := "0d0100001c0000002000000024000000680000009800000001010000000000000000000002000000aa00000000000000000000000000000000000000000000000000000000000001bb00000000000000000000000000000000000000000000000000000000000002010000000000000000000000ee046ce2baeda575266d4164f394c53f66009f64759f7a9f12a014c692e793900600000069000000080000006100000010000000180000006100000000406352bfc60100490000001000000030000000310000009bd7e06f3ecf4be0f2fcd2188b23f1b9fcc88e5d4b65a8637b17723bbda3cce80114000000c8328aabcd9b9e8e64fbc566c4385c3bdeb219d70c0000000800000000000000"
+
+	b, err := tx.Serialize()
+	if err != nil {
+		t.Errorf("fail to serialize: %s\n", err)
+		return
+	}
+
+	if hex.EncodeToString(b) != expectHex {
+		t.Errorf("mismatch result, expect %v, got %v", expectHex, hex.EncodeToString(b))
+		return
+	}
+
+	expectHash := Hash("0x474d68b07dda0cfe6399a04639f28aba497525092812ca642cbcd7cf5ed06bec")
+
+	h, err := tx.Hash()
+	if err != nil || h != expectHash {
+		t.Errorf("mismatch hash, expect %v, got %v (%v)", expectHash, h, err)
+		return
+	}
+
+	// Returned hashes are a copy
+	got[0] = "0x"
+	if tx.HeaderDeps[0] != expect[0] {
+		t.Errorf("expect header deps unchanged, got %v", tx.HeaderDeps[0])
+		return
+	}
+}
+
+func TestReplaceInputOutput(t *testing.T) {
+	lock := Script{
+		CodeHash: "0x9bd7e06f3ecf4be0f2fcd2188b23f1b9fcc88e5d4b65a8637b17723bbda3cce8",
+		HashType: Type,
+		Args:     "0xc8328aabcd9b9e8e64fbc566c4385c3bdeb219d7",
+	}
+
+	var tx Transaction
+	tx.Inputs = []CellInput{{Since: "0x0", PreviousOutput: OutPoint{TxHash: "0x99d6e242e482489434853da49922e3a24cb40212e1faf1715f82df9cea9a72e1", Index: "0x0"}}}
+	tx.AddOutput(&CellOutput{Capacity: "0x174876e800", Lock: lock}, EmptyBytes())
+
+	in := CellInput{Since: "0x0", PreviousOutput: OutPoint{TxHash: "0x99d6e242e482489434853da49922e3a24cb40212e1faf1715f82df9cea9a72e1", Index: "0x1"}}
+	err := tx.ReplaceInput(0, &in)
+	if err != nil {
+		t.Errorf("fail to replace input: %s\n", err)
+		return
+	}
+
+	if tx.Inputs[0] != in {
+		t.Errorf("mismatch result, expect %v, got %v", in, tx.Inputs[0])
+		return
+	}
+
+	err = tx.ReplaceInput(1, &in)
+	if err == nil {
+		t.Errorf("expect error on input index out of range")
+		return
+	}
+
+	out := CellOutput{Capacity: "0x2540be400", Lock: lock}
+	err = tx.ReplaceOutput(0, &out, "0x1234")
+	if err != nil {
+		t.Errorf("fail to replace output: %s\n", err)
+		return
+	}
+
+	if tx.Outputs[0].Capacity != out.Capacity || tx.OutputsData[0] != "0x1234" {
+		t.Errorf("mismatch result, expect %v, got %v", out, tx.Outputs[0])
+		return
+	}
+
+	err = tx.ReplaceOutput(-1, &out, "0x")
+	if err == nil {
+		t.Errorf("expect error on output index out of range")
+		return
+	}
+
+	tx.OutputsData = nil
+	err = tx.ReplaceOutput(0, &out, "0x")
+	if err == nil {
+		t.Errorf("expect error on misaligned outputs data")
+		return
+	}
+}
+
+func TestMergeDeps(t *testing.T) {
+	sighash := CellDep{
+		OutPoint: OutPoint{TxHash: "0xb815a396c5226009670e89ee514850dcde452bca746cdd6b41c104b50e559c70", Index: "0x0"},
+		DepType:  DepGroup,
+	}
+	dao := CellDep{
+		OutPoint: OutPoint{TxHash: "0xe2fb199810d49a4d8beec56718ba2593b665db9d52299a0f9e6e75416d73ff5c", Index: "0x2"},
+		DepType:  Code,
+	}
+	header := Hash("0x73f4ffa1d7898c2c044326363ba8d59fd123e173ff2d5c4d187d29baaae6214e")
+
+	a := Transaction{CellDeps: []CellDep{sighash}, HeaderDeps: []Hash{header}}
+	b := Transaction{
+		CellDeps: []CellDep{
+			{OutPoint: OutPoint{TxHash: "0xB815A396C5226009670E89EE514850DCDE452BCA746CDD6B41C104B50E559C70", Index: "0x0"}, DepType: DepGroup},
+			dao,
+		},
+		HeaderDeps: []Hash{"0x73F4FFA1D7898C2C044326363BA8D59FD123E173FF2D5C4D187D29BAAAE6214E"},
+	}
+
+	cellDeps, headerDeps := MergeDeps(&a, &b)
+
+	if len(cellDeps) != 2 || *cellDeps[0] != sighash || *cellDeps[1] != dao {
+		t.Errorf("mismatch result, expect %v, got %v", []CellDep{sighash, dao}, cellDeps)
+		return
+	}
+
+	if !reflect.DeepEqual(headerDeps, []Hash{header}) {
+		t.Errorf("mismatch result, expect %v, got %v", []Hash{header}, headerDeps)
+		return
+	}
+
+	// results are copies
+	cellDeps[0].DepType = Code
+	if a.CellDeps[0].DepType != DepGroup {
+		t.Errorf("mismatch result, expect %v, got %v", DepGroup, a.CellDeps[0].DepType)
+		return
+	}
+}
+
+func TestCellDepOutPoints(t *testing.T) {
+	var nilTx *Transaction
+	if nilTx.CellDepOutPoints() != nil {
+		t.Errorf("mismatch result, expect %v, got %v", nil, nilTx.CellDepOutPoints())
+		return
+	}
+
+	tx := Transaction{
+		CellDeps: []CellDep{
+			{OutPoint: OutPoint{TxHash: "0x71a7ba8fc96349fea0ed3a5c47992e3b4084b031a42264a018e0072e8172e46c", Index: "0x0"}, DepType: DepGroup},
+			{OutPoint: OutPoint{TxHash: "0xe2fb199810d49a4d8beec56718ba2593b665db9d52299a0f9e6e75416d73ff5c", Index: "0x2"}, DepType: Code},
+		},
+	}
+
+	got := tx.CellDepOutPoints()
+	if len(got) != 2 || *got[0] != tx.CellDeps[0].OutPoint || *got[1] != tx.CellDeps[1].OutPoint {
+		t.Errorf("mismatch result, expect %v, got %v", tx.CellDeps, got)
+		return
+	}
+
+	// Returned outpoints are copies
+	got[0].Index = "0x1"
+	if tx.CellDeps[0].OutPoint.Index != "0x0" {
+		t.Errorf("expect cell deps untouched, got %v", tx.CellDeps[0].OutPoint)
+		return
+	}
+
+	if len((&Transaction{}).CellDepOutPoints()) != 0 {
+		t.Errorf("mismatch result, expect %v, got %v", 0, len((&Transaction{}).CellDepOutPoints()))
+		return
+	}
+}
+
+func TestTransactionFee(t *testing.T) {
+	tx := Transaction{
+		Inputs: []CellInput{{}, {}},
+		Outputs: []CellOutput{
+			{Capacity: "0x174876e800"},
+			{Capacity: "0x2540be400"},
+		},
+	}
+
+	// 1000 ckb + 100 ckb out
+	fee, err := tx.Fee([]uint64{100000000000, 10000001000})
+	if err != nil || fee != 1000 {
+		t.Errorf("mismatch result, expect %v, got %v (%v)", 1000, fee, err)
+		return
+	}
+
+	_, err = tx.Fee([]uint64{100000000000, 9999999999})
+	if err == nil {
+		t.Errorf("expect error on outputs exceed inputs")
+		return
+	}
+
+	_, err = tx.Fee([]uint64{100000000000})
+	if err == nil {
+		t.Errorf("expect error on mismatched input capacities")
+		return
+	}
+
+	_, err = tx.Fee([]uint64{1<<64 - 1, 1})
+	if err == nil {
+		t.Errorf("expect error on input capacity overflow")
+		return
+	}
+}
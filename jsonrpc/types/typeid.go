@@ -0,0 +1,57 @@
+package types
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// TypeID type id args of output at index, created by transaction with firstInput
+/*
+ * Type id is blake2b-256 of the serialized first input of the creating
+ * transaction and the output index as u64 little-endian.
+ */
+func TypeID(firstInput *CellInput, index uint64) (Hash, error) {
+	b, err := firstInput.Serialize()
+	if err != nil {
+		return "", err
+	}
+
+	i := make([]byte, 8)
+	binary.LittleEndian.PutUint64(i, index)
+
+	return CKBHash(b, i), nil
+}
+
+// ValidateTypeIDOutputs check args of type id outputs against computed type id
+/*
+ * Only applies to transactions creating type id cells, a transaction
+ * moving an existing type id cell keeps args from its creation and fails
+ * this check.
+ */
+func (t *Transaction) ValidateTypeIDOutputs() error {
+	for i := 0; i < len(t.Outputs); i++ {
+		s := t.Outputs[i].Type
+		if s == nil {
+			continue
+		}
+
+		if name, ok := s.KnownScript(Mainnet); !ok || name != TypeIDScript {
+			continue
+		}
+
+		if len(t.Inputs) == 0 {
+			return fmt.Errorf("invalid type id outputs, transaction has no input")
+		}
+
+		expect, err := TypeID(&t.Inputs[0], uint64(i))
+		if err != nil {
+			return fieldError("inputs[0]", err)
+		}
+
+		if !Hash(s.Args).Equal(expect) {
+			return fmt.Errorf("outputs[%d] type id mismatch, expect %s, got %s", i, expect, s.Args)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package types
+
+import (
+	"testing"
+)
+
+func TestTypeID(t *testing.T) {
+	input := CellInput{
+		Since: "0x0",
+		PreviousOutput: OutPoint{
+			TxHash: "0xee046ce2baeda575266d4164f394c53f66009f64759f7a9f12a014c692e79390",
+			Index:  "0x6",
+		},
+	}
+
+	expect := Hash("0x5db93e91a984505b8188fb97a49707d43ff37d0bdad1b6741f159e26233eae00")
+
+	got, err := TypeID(&input, 1)
+	if err != nil {
+		t.Errorf("fail to compute type id: %s\n", err)
+		return
+	}
+
+	if got != expect {
+		t.Errorf("mismatch result, expect %v, got %v", expect, got)
+		return
+	}
+
+	lock := Script{
+		CodeHash: "0x9bd7e06f3ecf4be0f2fcd2188b23f1b9fcc88e5d4b65a8637b17723bbda3cce8",
+		HashType: Type,
+		Args:     "0xc8328aabcd9b9e8e64fbc566c4385c3bdeb219d7",
+	}
+
+	typeID := Script{
+		CodeHash: "0x00000000000000000000000000000000000000000000000000545950455f4944",
+		HashType: Type,
+		Args:     Bytes(expect),
+	}
+
+	tx := Transaction{
+		Inputs: []CellInput{input},
+		Outputs: []CellOutput{
+			{Capacity: "0x0", Lock: lock},
+			{Capacity: "0x0", Lock: lock, Type: &typeID},
+		},
+	}
+
+	err = tx.ValidateTypeIDOutputs()
+	if err != nil {
+		t.Errorf("fail to validate type id outputs: %s\n", err)
+		return
+	}
+
+	// Type id of output 0 at index 1
+	tx.Outputs = []CellOutput{tx.Outputs[1], tx.Outputs[0]}
+
+	err = tx.ValidateTypeIDOutputs()
+	if err == nil {
+		t.Errorf("expect error on mismatched type id")
+		return
+	}
+}
@@ -0,0 +1,76 @@
+package types
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strconv"
+)
+
+// newUint32 format uint32 into canonical minimal hex
+func newUint32(n uint32) Uint32 {
+	return Uint32(fmt.Sprintf("0x%x", n))
+}
+
+// newUint64 format uint64 into canonical minimal hex
+func newUint64(n uint64) Uint64 {
+	return Uint64(fmt.Sprintf("0x%x", n))
+}
+
+// parseUint parse 0x-prefix hex number
+func parseUint(s string, bitSize int) (uint64, error) {
+	err := check0xPrefix(s)
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.ParseUint(s[2:], 16, bitSize)
+}
+
+// Uint32 parse uint32 value
+func (u *Uint32) Uint32() (uint32, error) {
+	n, err := parseUint(string(*u), 32)
+	if err != nil {
+		return 0, err
+	}
+
+	return uint32(n), nil
+}
+
+// Uint64 parse uint64 value
+func (u *Uint64) Uint64() (uint64, error) {
+	return parseUint(string(*u), 64)
+}
+
+// SerializeBE serialize uint32 in big-endian
+/*
+ * Molecule uses little-endian, only use this for non-molecule payloads
+ * such as big-endian cell data of other protocols.
+ */
+func (u *Uint32) SerializeBE() ([]byte, error) {
+	n, err := u.Uint32()
+	if err != nil {
+		return nil, err
+	}
+
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, n)
+
+	return b, nil
+}
+
+// SerializeBE serialize uint64 in big-endian
+/*
+ * Molecule uses little-endian, only use this for non-molecule payloads
+ * such as big-endian cell data of other protocols.
+ */
+func (u *Uint64) SerializeBE() ([]byte, error) {
+	n, err := u.Uint64()
+	if err != nil {
+		return nil, err
+	}
+
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, n)
+
+	return b, nil
+}
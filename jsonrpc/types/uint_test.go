@@ -0,0 +1,55 @@
+package types
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestUintAccessor(t *testing.T) {
+	c := Uint64("0x1c6bf52634000")
+
+	got, err := c.Uint64()
+	if err != nil {
+		t.Errorf("fail to parse uint64: %s\n", err)
+		return
+	}
+
+	if got != 500000000000000 {
+		t.Errorf("mismatch result, expect %v, got %v", 500000000000000, got)
+		return
+	}
+
+	i := Uint32("0x100000000")
+
+	_, err = i.Uint32()
+	if err == nil {
+		t.Errorf("expect error on uint32 overflow")
+		return
+	}
+
+	i = Uint32("6")
+
+	_, err = i.Uint32()
+	if err == nil {
+		t.Errorf("expect error on missing 0x prefix")
+		return
+	}
+}
+
+func TestSerializeBE(t *testing.T) {
+	u32 := Uint32("0x666")
+
+	got, err := u32.SerializeBE()
+	if err != nil || hex.EncodeToString(got) != "00000666" {
+		t.Errorf("mismatch result, expect %v, got %x (%v)", "00000666", got, err)
+		return
+	}
+
+	u64 := Uint64("0x1c6bf52634000")
+
+	got, err = u64.SerializeBE()
+	if err != nil || hex.EncodeToString(got) != "0001c6bf52634000" {
+		t.Errorf("mismatch result, expect %v, got %x (%v)", "0001c6bf52634000", got, err)
+		return
+	}
+}
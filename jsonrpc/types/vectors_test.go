@@ -0,0 +1,152 @@
+//go:build ckbvectors
+// +build ckbvectors
+
+package types
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// ckbVector transaction exported by ckb-cli or sdk with expected results
+type ckbVector struct {
+	Transaction Transaction `json:"transaction"`
+	Serialized  string      `json:"serialized"`
+	Hash        Hash        `json:"hash"`
+}
+
+// ckbVectorsDir directory of vectors, override through CKB_VECTORS_DIR
+func ckbVectorsDir() string {
+	if dir := os.Getenv("CKB_VECTORS_DIR"); dir != "" {
+		return dir
+	}
+
+	return filepath.Join("testdata", "ckbvectors")
+}
+
+func TestCkbVectors(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join(ckbVectorsDir(), "*.json"))
+	if err != nil {
+		t.Errorf("fail to list vectors: %s\n", err)
+		return
+	}
+
+	if len(files) == 0 {
+		t.Errorf("no vectors found in %s", ckbVectorsDir())
+		return
+	}
+
+	for _, file := range files {
+		raw, err := ioutil.ReadFile(file)
+		if err != nil {
+			t.Errorf("fail to read vector %s: %s\n", file, err)
+			continue
+		}
+
+		var v ckbVector
+
+		err = json.Unmarshal(raw, &v)
+		if err != nil {
+			t.Errorf("fail to unmarshal vector %s: %s\n", file, err)
+			continue
+		}
+
+		got, err := v.Transaction.Serialize()
+		if err != nil {
+			t.Errorf("fail to serialize vector %s: %s\n", file, err)
+			continue
+		}
+
+		gotHex := "0x" + hex.EncodeToString(got)
+		if gotHex != v.Serialized {
+			t.Errorf("mismatch serialized %s, expect %v, got %v", file, v.Serialized, gotHex)
+			continue
+		}
+
+		gotHash := CKBHash(got)
+		if gotHash != v.Hash {
+			t.Errorf("mismatch hash %s, expect %v, got %v", file, v.Hash, gotHash)
+			continue
+		}
+
+		tx, err := DeserializeTransaction(got)
+		if err != nil {
+			t.Errorf("fail to deserialize vector %s: %s\n", file, err)
+			continue
+		}
+
+		// Witnesses aren't part of the raw transaction
+		expect := v.Transaction
+		expect.Witnesses = make([]Bytes, 0)
+
+		if !reflect.DeepEqual(&expect, tx) {
+			t.Errorf("mismatch deserialized %s, expect %v, got %v", file, expect, *tx)
+			continue
+		}
+	}
+}
+
+// TestCkbBlockVectors check transactions root of blocks in <vectors>/blocks
+/*
+ * Each file is a get_block result from a CKB node, the transactions root
+ * computed from its transactions must match its header.
+ */
+func TestCkbBlockVectors(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join(ckbVectorsDir(), "blocks", "*.json"))
+	if err != nil {
+		t.Errorf("fail to list block vectors: %s\n", err)
+		return
+	}
+
+	if len(files) == 0 {
+		t.Skipf("no block vectors found in %s", filepath.Join(ckbVectorsDir(), "blocks"))
+	}
+
+	for _, file := range files {
+		raw, err := ioutil.ReadFile(file)
+		if err != nil {
+			t.Errorf("fail to read block vector %s: %s\n", file, err)
+			continue
+		}
+
+		var block Block
+
+		err = json.Unmarshal(raw, &block)
+		if err != nil {
+			t.Errorf("fail to unmarshal block vector %s: %s\n", file, err)
+			continue
+		}
+
+		txHashes := make([]Hash, len(block.Transactions))
+		witnessHashes := make([]Hash, len(block.Transactions))
+		for i := 0; i < len(block.Transactions); i++ {
+			txHashes[i], err = block.Transactions[i].Hash()
+			if err == nil {
+				witnessHashes[i], err = block.Transactions[i].WitnessHash()
+			}
+			if err != nil {
+				t.Errorf("fail to hash block vector %s transactions[%d]: %s\n", file, i, err)
+				break
+			}
+		}
+		if err != nil {
+			continue
+		}
+
+		got, err := TransactionsRoot(txHashes, witnessHashes)
+		if err != nil {
+			t.Errorf("fail to compute transactions root %s: %s\n", file, err)
+			continue
+		}
+
+		if got != block.Header.TransactionsRoot {
+			t.Errorf("mismatch transactions root %s, expect %v, got %v", file, block.Header.TransactionsRoot, got)
+			continue
+		}
+	}
+}
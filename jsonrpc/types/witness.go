@@ -0,0 +1,42 @@
+package types
+
+// secp256k1SignatureSize recoverable secp256k1 signature size
+const secp256k1SignatureSize = 65
+
+// PlaceholderWitness serialized witness args with zeroed 65 bytes lock
+/*
+ * The first witness of a lock group is signed with its lock zero filled,
+ * replace the placeholder with real signature by SetWitnessLock.
+ */
+func PlaceholderWitness() Bytes {
+	lock := BytesFromRaw(make([]byte, secp256k1SignatureSize))
+	w := WitnessArgs{Lock: &lock}
+
+	// Lock is valid hex, serialize never fails
+	b, _ := w.Serialize()
+
+	return BytesFromRaw(b)
+}
+
+// SetWitnessLock replace lock of serialized witness args with signature
+func SetWitnessLock(witness Bytes, signature []byte) (Bytes, error) {
+	raw, err := witness.Raw()
+	if err != nil {
+		return "", err
+	}
+
+	w, err := DeserializeWitnessArgs(raw)
+	if err != nil {
+		return "", err
+	}
+
+	lock := BytesFromRaw(signature)
+	w.Lock = &lock
+
+	b, err := w.Serialize()
+	if err != nil {
+		return "", err
+	}
+
+	return BytesFromRaw(b), nil
+}
@@ -0,0 +1,72 @@
+package types
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestPlaceholderWitness(t *testing.T) {
+	expect := Bytes("0x55000000100000005500000055000000" + "41000000" + strings.Repeat("00", 65))
+
+	got := PlaceholderWitness()
+	if got != expect {
+		t.Errorf("mismatch result, expect %v, got %v", expect, got)
+		return
+	}
+}
+
+func TestSetWitnessLock(t *testing.T) {
+	typeArgs := Bytes("0x1234")
+	w := WitnessArgs{InputType: &typeArgs}
+
+	b, err := w.Serialize()
+	if err != nil {
+		t.Errorf("fail to serialize: %s\n", err)
+		return
+	}
+
+	signature := bytes.Repeat([]byte{0xab}, 65)
+
+	witness, err := SetWitnessLock(BytesFromRaw(b), signature)
+	if err != nil {
+		t.Errorf("fail to set witness lock: %s\n", err)
+		return
+	}
+
+	raw, _ := witness.Raw()
+
+	got, err := DeserializeWitnessArgs(raw)
+	if err != nil {
+		t.Errorf("fail to deserialize: %s\n", err)
+		return
+	}
+
+	if got.Lock == nil || *got.Lock != BytesFromRaw(signature) {
+		t.Errorf("mismatch lock, expect %v, got %v", BytesFromRaw(signature), got.Lock)
+		return
+	}
+
+	if got.InputType == nil || *got.InputType != typeArgs || got.OutputType != nil {
+		t.Errorf("mismatch other fields, got %v %v", got.InputType, got.OutputType)
+		return
+	}
+
+	// Replace placeholder keeps the size
+	witness, err = SetWitnessLock(PlaceholderWitness(), signature)
+	if err != nil {
+		t.Errorf("fail to set witness lock: %s\n", err)
+		return
+	}
+
+	if len(witness) != len(PlaceholderWitness()) {
+		t.Errorf("mismatch witness length, expect %v, got %v", len(PlaceholderWitness()), len(witness))
+		return
+	}
+
+	_, err = SetWitnessLock("0x1234", signature)
+	if err == nil {
+		t.Errorf("expect error on invalid witness args")
+		return
+	}
+}